@@ -2,9 +2,12 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"os"
 	"phasor/backend/internal/app"
 	"phasor/backend/internal/config"
+	"phasor/shared/lifecycle"
 
 	"github.com/monkescience/vital"
 )
@@ -12,10 +15,21 @@ import (
 const serverPort = 8080
 
 func main() {
-	configPath := flag.String("config", "/config/config.yaml", "Path to the configuration file")
+	configPath := flag.String("config", "", "Path to the configuration file (default: built-in defaults only)")
+
+	flag.Usage = usage
 
 	flag.Parse()
 
+	if flag.NArg() > 0 {
+		err := app.RunCommand(flag.Arg(0), flag.Args()[1:], *configPath, os.Stdout)
+		if err != nil {
+			log.Fatalf("%s: %v", flag.Arg(0), err)
+		}
+
+		return
+	}
+
 	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
@@ -30,7 +44,29 @@ func main() {
 		log.Fatalf("failed to setup logger: %v", err)
 	}
 
-	router := app.SetupRouter(cfg, logger)
+	shutdownChecker := lifecycle.NewShutdownChecker()
+	router := app.SetupRouter(cfg, logger, shutdownChecker)
+	server := vital.NewServer(
+		router,
+		vital.WithPort(serverPort),
+		vital.WithShutdownTimeout(*cfg.Shutdown.Timeout),
+		vital.WithLogger(logger),
+	)
+
+	err = lifecycle.Run(server, shutdownChecker, *cfg.Shutdown.DrainPeriod, logger)
+	if err != nil {
+		log.Fatalf("failed to run server: %v", err)
+	}
+}
+
+func usage() {
+	output := flag.CommandLine.Output()
 
-	vital.NewServer(router, vital.WithPort(serverPort), vital.WithLogger(logger)).Run()
+	fmt.Fprintf(output, "Usage: %s [-config path] [command]\n\n", os.Args[0])
+	fmt.Fprintln(output, "Without a command the server is started. Commands:")
+	fmt.Fprintln(output, "  validate         Load and validate the config file")
+	fmt.Fprintln(output, "  print-effective  Print the config with defaults and environment variables applied")
+	fmt.Fprintln(output, "  schema           Print the JSON Schema of the config file")
+	fmt.Fprintln(output, "\nFlags:")
+	flag.PrintDefaults()
 }
@@ -2,12 +2,16 @@ module phasor/backend
 
 go 1.25.5
 
+replace phasor/shared => ../shared
+
 require (
 	github.com/getkin/kin-openapi v0.133.0
 	github.com/go-chi/chi/v5 v5.2.3
+	github.com/monkescience/testastic v0.0.0-20251216213937-22bb94593d66
 	github.com/monkescience/vital v0.0.0-20251223172315-8503480c42fe
 	github.com/oapi-codegen/oapi-codegen/v2 v2.5.1
 	gopkg.in/yaml.v3 v3.0.1
+	phasor/shared v0.0.0
 )
 
 require (
@@ -30,6 +34,7 @@ require (
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/term v0.38.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
@@ -0,0 +1,82 @@
+// Package admin provides authenticated operator endpoints for demoing failure scenarios.
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"phasor/backend/internal/health"
+	"time"
+
+	"github.com/monkescience/vital"
+)
+
+const maxFaultDuration = time.Hour
+
+// HealthFaultRequest is the request body for forcing a health probe to fail.
+type HealthFaultRequest struct {
+	// Probe is the probe to fail, either "liveness" or "readiness".
+	Probe string `json:"probe"`
+	// Duration is how long the probe fails, e.g. "30s". Zero clears an active fault.
+	Duration string `json:"duration"`
+}
+
+// HealthFaultResponse describes the fault that is now active.
+type HealthFaultResponse struct {
+	Probe        string    `json:"probe"`
+	FailingUntil time.Time `json:"failing_until"`
+}
+
+// AdminHandler handles admin requests.
+type AdminHandler struct {
+	faults *health.FaultChecker
+}
+
+// NewAdminHandler creates a new admin handler that toggles faults on the given checker.
+func NewAdminHandler(faults *health.FaultChecker) *AdminHandler {
+	return &AdminHandler{
+		faults: faults,
+	}
+}
+
+// SetHealthFault forces the liveness or readiness probe to fail for the requested duration.
+func (h *AdminHandler) SetHealthFault(writer http.ResponseWriter, req *http.Request) {
+	var body HealthFaultRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		vital.RespondProblem(writer, vital.BadRequest(fmt.Sprintf("invalid request body: %v", err)))
+
+		return
+	}
+
+	probe := health.Probe(body.Probe)
+	if probe != health.ProbeLiveness && probe != health.ProbeReadiness {
+		vital.RespondProblem(writer, vital.BadRequest(`probe must be "liveness" or "readiness"`))
+
+		return
+	}
+
+	duration, err := time.ParseDuration(body.Duration)
+	if err != nil || duration < 0 || duration > maxFaultDuration {
+		vital.RespondProblem(writer, vital.BadRequest(
+			fmt.Sprintf("duration must be a duration between 0s and %s", maxFaultDuration),
+		))
+
+		return
+	}
+
+	response := HealthFaultResponse{
+		Probe:        body.Probe,
+		FailingUntil: h.faults.FailFor(probe, duration),
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	encodeErr := json.NewEncoder(writer).Encode(response)
+	if encodeErr != nil {
+		http.Error(writer, "failed to encode response", http.StatusInternalServerError)
+
+		return
+	}
+}
@@ -1,9 +1,16 @@
 package app
 
 import (
+	"encoding/json"
 	"log/slog"
+	"net/http"
 	"os"
+	"phasor/backend/internal/admin"
 	"phasor/backend/internal/config"
+	"phasor/backend/internal/health"
+	"phasor/backend/internal/pressure"
+	"phasor/shared/lifecycle"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/monkescience/vital"
@@ -12,30 +19,71 @@ import (
 )
 
 // SetupRouter creates and configures the application router with all middleware and handlers.
-func SetupRouter(cfg *config.Config, logger *slog.Logger) *chi.Mux {
-	return SetupRouterWithHostname(cfg, logger, systemHostname)
+// The shutdown checker is registered as a readiness check so that draining fails readiness.
+func SetupRouter(cfg *config.Config, logger *slog.Logger, shutdownChecker *lifecycle.ShutdownChecker) *chi.Mux {
+	return SetupRouterWithHostnameAndClock(cfg, logger, shutdownChecker, systemHostname, time.Now)
 }
 
-// SetupRouterWithHostname creates and configures the application router with a custom hostname function.
-// This is primarily useful for testing with deterministic hostnames.
-func SetupRouterWithHostname(cfg *config.Config, logger *slog.Logger, getHostname instanceapi.HostnameFunc) *chi.Mux {
+// SetupRouterWithHostnameAndClock creates and configures the application router with a custom
+// hostname function and clock. This is primarily useful for testing with deterministic hostnames,
+// timestamps and uptimes.
+func SetupRouterWithHostnameAndClock(
+	cfg *config.Config,
+	logger *slog.Logger,
+	shutdownChecker *lifecycle.ShutdownChecker,
+	getHostname instanceapi.HostnameFunc,
+	now instanceapi.ClockFunc,
+) *chi.Mux {
 	router := chi.NewRouter()
 	router.Use(vital.Recovery(logger))
 
+	checkers := []vital.Checker{shutdownChecker}
+	if cfg.Startup.WarmupPeriod > 0 || cfg.Startup.RequiredSelfChecks > 0 {
+		checkers = append(checkers, health.NewWarmupChecker(cfg.Startup.WarmupPeriod, cfg.Startup.RequiredSelfChecks))
+	}
+
+	faultChecker := health.NewFaultChecker()
+	if cfg.Admin.Enabled {
+		checkers = append(checkers, faultChecker)
+	}
+
 	healthHandler := vital.NewHealthHandler(
 		vital.WithVersion(cfg.Version),
 		vital.WithEnvironment(cfg.Environment),
+		vital.WithCheckers(checkers...),
 	)
-	router.Mount("/health", healthHandler)
+	router.Mount("/health", livenessFault(faultChecker, healthHandler))
 
 	router.Group(func(r chi.Router) {
 		r.Use(vital.TraceContext())
 		r.Use(vital.RequestLogger(logger))
 
-		instanceHandler := instanceapi.NewInstanceHandler(cfg.Version, getHostname)
+		instanceHandler := instanceapi.NewInstanceHandler(
+			cfg.Version,
+			getHostname,
+			now,
+			pressure.NewSimulator(now),
+			instanceapi.PressureLimits{
+				Enabled:     cfg.Pressure.Enabled,
+				MaxCPUCores: cfg.Pressure.MaxCPUCores,
+				MaxMemoryMB: cfg.Pressure.MaxMemoryMB,
+				MaxDuration: cfg.Pressure.MaxDuration,
+			},
+		)
 		instanceapi.HandlerFromMux(instanceHandler, r)
 	})
 
+	if cfg.Admin.Enabled {
+		router.Group(func(r chi.Router) {
+			r.Use(vital.TraceContext())
+			r.Use(vital.RequestLogger(logger))
+			r.Use(vital.BasicAuth(cfg.Admin.Username, cfg.Admin.Password, "phasor-admin"))
+
+			adminHandler := admin.NewAdminHandler(faultChecker)
+			r.Post("/admin/health", adminHandler.SetHealthFault)
+		})
+	}
+
 	return router
 }
 
@@ -48,3 +96,21 @@ func systemHostname() string {
 
 	return hostname
 }
+
+// livenessFault wraps the health handler so that /health/live fails while a liveness fault is active.
+func livenessFault(faults *health.FaultChecker, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		failing, _ := faults.Failing(health.ProbeLiveness)
+		if !failing || req.URL.Path != "/health/live" {
+			next.ServeHTTP(writer, req)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Cache-Control", "no-store, no-cache")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+
+		_ = json.NewEncoder(writer).Encode(vital.LiveResponse{Status: vital.StatusError}) //nolint:errchkjson
+	})
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"io"
+	"phasor/backend/internal/config"
+	"phasor/shared/configkit"
+)
+
+// RunCommand runs the named config command, see configkit.Commands. validate checks the config
+// file alone, print-effective loads it exactly like at server start, so VERSION must be set.
+func RunCommand(name string, args []string, configPath string, stdout io.Writer) error {
+	//nolint:wrapcheck // The error is already wrapped by configkit.
+	return configkit.Commands[config.Config]{
+		Load:        config.Load,
+		LoadFile:    config.LoadFile,
+		JSONSchema:  config.JSONSchema,
+		EnvComments: envComments,
+	}.Run(name, args, configPath, stdout)
+}
+
+// envComments describes the values only read from the environment. The admin password is
+// never printed, only whether it is set.
+func envComments(cfg *config.Config) []string {
+	adminPassword := "not set"
+	if cfg.Admin.Password != "" {
+		adminPassword = "set"
+	}
+
+	return []string{
+		"version: " + cfg.Version + " (VERSION)",
+		"admin password: " + adminPassword + " (ADMIN_PASSWORD)",
+	}
+}
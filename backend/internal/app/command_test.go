@@ -0,0 +1,74 @@
+package app_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"phasor/backend/internal/app"
+	"phasor/shared/configkit"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	testastic.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestRunCommand(t *testing.T) {
+	t.Setenv("VERSION", "1.0.0")
+	t.Setenv("ADMIN_PASSWORD", "s3cret")
+
+	t.Run("print-effective does not print the admin password", func(t *testing.T) {
+		// GIVEN: a config file with the admin API enabled
+		path := writeConfig(t, `
+environment: "test"
+log_config: {level: "info", format: "json"}
+admin: {enabled: true, username: "admin"}
+`)
+
+		var stdout bytes.Buffer
+
+		// WHEN: running print-effective
+		err := app.RunCommand(configkit.CommandPrintEffective, []string{"-config", path}, "", &stdout)
+
+		// THEN: only whether the password is set is printed
+		testastic.NoError(t, err)
+		testastic.Contains(t, stdout.String(), "# admin password: set (ADMIN_PASSWORD)\n")
+		testastic.Contains(t, stdout.String(), "admin:\n  enabled: true\n  username: admin\n")
+		testastic.NotContains(t, stdout.String(), "s3cret")
+	})
+
+	t.Run("validate checks the config file without the environment", func(t *testing.T) {
+		// GIVEN: a config file with the admin API enabled, and neither VERSION nor ADMIN_PASSWORD set
+		t.Setenv("VERSION", "")
+		t.Setenv("ADMIN_PASSWORD", "")
+
+		path := writeConfig(t, `admin: {enabled: true}`)
+
+		var stdout bytes.Buffer
+
+		// WHEN: running validate
+		err := app.RunCommand(configkit.CommandValidate, nil, path, &stdout)
+
+		// THEN: the file is valid, the environment is checked at server start
+		testastic.NoError(t, err)
+		testastic.Equal(t, path+": valid\n", stdout.String())
+	})
+
+	t.Run("validate returns the validation errors", func(t *testing.T) {
+		// GIVEN: a config file with an invalid log format
+		path := writeConfig(t, `log_config: {format: "yaml"}`)
+
+		// WHEN: running validate
+		err := app.RunCommand(configkit.CommandValidate, nil, path, &bytes.Buffer{})
+
+		// THEN: the missing field is reported
+		testastic.ErrorContains(t, err, "log_config.format: invalid value")
+	})
+}
@@ -3,19 +3,45 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"phasor/shared/configkit"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// DefaultDrainPeriod is how long the server keeps serving after readiness starts failing.
+	DefaultDrainPeriod = 5 * time.Second
+	// DefaultEnvironment is the environment name used when none is configured.
+	DefaultEnvironment = "default"
+	// DefaultLogLevel is the log level used when none is configured.
+	DefaultLogLevel = "info"
+	// DefaultLogFormat is the log format used when none is configured.
+	DefaultLogFormat = "json"
+	// DefaultAdminUsername is the basic auth username of the admin API used when none is configured.
+	DefaultAdminUsername = "admin"
+	// DefaultShutdownTimeout is how long the server waits for in-flight requests during shutdown.
+	DefaultShutdownTimeout = 15 * time.Second
+	// DefaultPressureMaxCPUCores is the default maximum number of cores a CPU pressure request may burn.
+	DefaultPressureMaxCPUCores = 1
+	// DefaultPressureMaxMemoryMB is the default maximum memory a memory pressure request may retain.
+	DefaultPressureMaxMemoryMB = 64
+	// DefaultPressureMaxDuration is the default maximum duration of a pressure request.
+	DefaultPressureMaxDuration = 5 * time.Minute
+)
+
 var (
 	// ErrVersionRequired is returned when the VERSION environment variable is not set.
 	ErrVersionRequired = errors.New("VERSION environment variable is required")
 	// ErrConfigPathNotAbsolute is returned when the config file path is not absolute.
 	ErrConfigPathNotAbsolute = errors.New("config file path must be absolute")
-	// ErrEnvironmentRequired is returned when environment is not configured in the config file.
-	ErrEnvironmentRequired = errors.New("environment must be configured in the config file")
+	// ErrAdminPasswordRequired is returned when the admin API is enabled without a password.
+	ErrAdminPasswordRequired = errors.New(
+		"ADMIN_PASSWORD environment variable is required when the admin API is enabled",
+	)
 )
 
 // Config holds the backend application configuration.
@@ -27,19 +53,90 @@ type Config struct {
 		Format    string `yaml:"format"`     // Log format (json, text)
 		AddSource bool   `yaml:"add_source"` // Include source file and line number
 	} `yaml:"log_config"`
+	Shutdown struct {
+		DrainPeriod *time.Duration `yaml:"drain_period"` // Time to keep serving after readiness starts failing, 0s: none
+		Timeout     *time.Duration `yaml:"timeout"`      // Maximum time to wait for in-flight requests
+	} `yaml:"shutdown"`
+	Startup struct {
+		WarmupPeriod       time.Duration `yaml:"warmup_period"`        // Time to stay not ready after start
+		RequiredSelfChecks int           `yaml:"required_self_checks"` // Passed readiness checks required after warm-up
+	} `yaml:"startup"`
+	Admin struct {
+		Enabled  bool   `yaml:"enabled"`  // Expose the authenticated admin API under /admin
+		Username string `yaml:"username"` // Basic auth username for the admin API
+		Password string `yaml:"-"`        // Password must be set via ADMIN_PASSWORD environment variable only
+	} `yaml:"admin"`
+	Pressure struct {
+		Enabled     bool          `yaml:"enabled"`       // Expose the CPU and memory pressure endpoints
+		MaxCPUCores int           `yaml:"max_cpu_cores"` // Maximum cores a single request may burn
+		MaxMemoryMB int           `yaml:"max_memory_mb"` // Maximum megabytes a single request may retain
+		MaxDuration time.Duration `yaml:"max_duration"`  // Maximum duration of a single request
+	} `yaml:"pressure"`
 }
 
 // Load reads configuration from the specified YAML file and environment variables.
 // The VERSION environment variable is required and must be set; it cannot be configured via the config file.
+// The admin password is read from the ADMIN_PASSWORD environment variable.
+// Fields missing from the file get their defaults, and an empty path runs on the defaults alone.
+// Unknown keys are rejected, and all invalid fields are reported at once with their YAML paths.
 func Load(path string) (*Config, error) {
-	cleanPath := filepath.Clean(path)
-	if !filepath.IsAbs(cleanPath) {
-		return nil, fmt.Errorf("%w: %s", ErrConfigPathNotAbsolute, path)
+	return load(path, true)
+}
+
+// LoadFile reads and validates only the configuration file, like Load without the values that
+// are only read from the environment, e.g. to check a rendered ConfigMap before it is deployed.
+func LoadFile(path string) (*Config, error) {
+	return load(path, false)
+}
+
+func load(path string, fromEnv bool) (*Config, error) {
+	var cfg Config
+
+	source := "(no config file)"
+
+	// Unknown keys and mistyped values are reported together with the validation errors.
+	var decodeErr error
+
+	if path != "" {
+		cleanPath := filepath.Clean(path)
+		if !filepath.IsAbs(cleanPath) {
+			return nil, fmt.Errorf("%w: %s", ErrConfigPathNotAbsolute, path)
+		}
+
+		source = cleanPath
+
+		var typeErr *yaml.TypeError
+
+		decodeErr = decodeFile(cleanPath, &cfg)
+		if decodeErr != nil && !errors.As(decodeErr, &typeErr) {
+			return nil, decodeErr
+		}
+	}
+
+	var envErr error
+
+	if fromEnv {
+		cfg.Version = os.Getenv("VERSION")
+		cfg.Admin.Password = os.Getenv("ADMIN_PASSWORD")
+		envErr = cfg.validateEnv()
+	}
+
+	cfg.applyDefaults()
+
+	err := errors.Join(decodeErr, envErr, cfg.validate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", source, err)
 	}
 
-	configFile, err := os.Open(cleanPath)
+	return &cfg, nil
+}
+
+// decodeFile decodes the YAML file at path into cfg, rejecting unknown keys. An empty file
+// leaves cfg unchanged.
+func decodeFile(path string, cfg *Config) (err error) {
+	configFile, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %w", err)
+		return fmt.Errorf("failed to open config file: %w", err)
 	}
 
 	defer func() {
@@ -49,23 +146,89 @@ func Load(path string) (*Config, error) {
 		}
 	}()
 
-	var cfg Config
-
 	decoder := yaml.NewDecoder(configFile)
+	decoder.KnownFields(true)
 
-	err = decoder.Decode(&cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode config: %w", err)
+	err = decoder.Decode(cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode config: %w", err)
 	}
 
-	cfg.Version = os.Getenv("VERSION")
-	if cfg.Version == "" {
-		return nil, ErrVersionRequired
+	return nil
+}
+
+// validateEnv checks the values that are only read from the environment.
+func (c *Config) validateEnv() error {
+	var v configkit.Validator
+
+	if c.Version == "" {
+		v.AddError(ErrVersionRequired)
 	}
 
-	if cfg.Environment == "" {
-		return nil, ErrEnvironmentRequired
+	if c.Admin.Enabled && c.Admin.Password == "" {
+		v.AddError(ErrAdminPasswordRequired)
 	}
 
-	return &cfg, nil
+	return v.Err()
+}
+
+// validate checks every field of the config file and returns all problems joined, each with
+// its YAML path.
+func (c *Config) validate() error {
+	var v configkit.Validator
+
+	v.OneOf("log_config.level", c.LogConfig.Level, logLevels...)
+	v.OneOf("log_config.format", c.LogConfig.Format, logFormats...)
+	v.NonNegative("shutdown.drain_period", int64(*c.Shutdown.DrainPeriod))
+	v.NonNegative("shutdown.timeout", int64(*c.Shutdown.Timeout))
+	v.NonNegative("startup.warmup_period", int64(c.Startup.WarmupPeriod))
+	v.NonNegative("startup.required_self_checks", int64(c.Startup.RequiredSelfChecks))
+
+	v.NonNegative("pressure.max_cpu_cores", int64(c.Pressure.MaxCPUCores))
+	v.NonNegative("pressure.max_memory_mb", int64(c.Pressure.MaxMemoryMB))
+	v.NonNegative("pressure.max_duration", int64(c.Pressure.MaxDuration))
+
+	return v.Err()
+}
+
+// applyDefaults fills in the defaults of every field that is not configured.
+func (c *Config) applyDefaults() {
+	if c.Environment == "" {
+		c.Environment = DefaultEnvironment
+	}
+
+	if c.LogConfig.Level == "" {
+		c.LogConfig.Level = DefaultLogLevel
+	}
+
+	if c.LogConfig.Format == "" {
+		c.LogConfig.Format = DefaultLogFormat
+	}
+
+	if c.Admin.Username == "" {
+		c.Admin.Username = DefaultAdminUsername
+	}
+
+	if c.Pressure.MaxCPUCores == 0 {
+		c.Pressure.MaxCPUCores = DefaultPressureMaxCPUCores
+	}
+
+	if c.Pressure.MaxMemoryMB == 0 {
+		c.Pressure.MaxMemoryMB = DefaultPressureMaxMemoryMB
+	}
+
+	if c.Pressure.MaxDuration == 0 {
+		c.Pressure.MaxDuration = DefaultPressureMaxDuration
+	}
+
+	// The shutdown durations are pointers, so that an explicit 0s is kept.
+	if c.Shutdown.DrainPeriod == nil {
+		drainPeriod := DefaultDrainPeriod
+		c.Shutdown.DrainPeriod = &drainPeriod
+	}
+
+	if c.Shutdown.Timeout == nil {
+		timeout := DefaultShutdownTimeout
+		c.Shutdown.Timeout = &timeout
+	}
 }
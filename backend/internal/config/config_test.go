@@ -0,0 +1,178 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"phasor/backend/internal/config"
+	"phasor/shared/configkit"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+const validConfig = `
+environment: "test"
+log_config:
+  level: "info"
+  format: "json"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	testastic.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("VERSION", "1.0.0")
+	t.Setenv("ADMIN_PASSWORD", "")
+
+	t.Run("valid config is loaded with defaults", func(t *testing.T) {
+		// GIVEN: a config with only the environment and logging configured
+		path := writeConfig(t, validConfig)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: optional fields get their defaults
+		testastic.NoError(t, err)
+		testastic.Equal(t, "1.0.0", cfg.Version)
+		testastic.Equal(t, config.DefaultDrainPeriod, *cfg.Shutdown.DrainPeriod)
+		testastic.Equal(t, config.DefaultPressureMaxCPUCores, cfg.Pressure.MaxCPUCores)
+		testastic.Equal(t, config.DefaultPressureMaxDuration, cfg.Pressure.MaxDuration)
+	})
+
+	t.Run("without a config file only the defaults are used", func(t *testing.T) {
+		// WHEN: loading the config without a path
+		cfg, err := config.Load("")
+
+		// THEN: every field gets its default
+		testastic.NoError(t, err)
+		testastic.Equal(t, config.DefaultEnvironment, cfg.Environment)
+		testastic.Equal(t, config.DefaultLogLevel, cfg.LogConfig.Level)
+		testastic.Equal(t, config.DefaultLogFormat, cfg.LogConfig.Format)
+		testastic.Equal(t, config.DefaultAdminUsername, cfg.Admin.Username)
+		testastic.Equal(t, config.DefaultShutdownTimeout, *cfg.Shutdown.Timeout)
+	})
+
+	t.Run("explicit zero shutdown durations are kept", func(t *testing.T) {
+		// GIVEN: a config that turns the drain off and cancels in-flight requests right away
+		path := writeConfig(t, validConfig+`shutdown: {drain_period: "0s", timeout: "0s"}`)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: the zeros are not replaced with the defaults
+		testastic.NoError(t, err)
+		testastic.Equal(t, time.Duration(0), *cfg.Shutdown.DrainPeriod)
+		testastic.Equal(t, time.Duration(0), *cfg.Shutdown.Timeout)
+	})
+
+	t.Run("empty config file uses the defaults", func(t *testing.T) {
+		// GIVEN: an empty config file
+		path := writeConfig(t, "")
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: the defaults are used
+		testastic.NoError(t, err)
+		testastic.Equal(t, config.DefaultEnvironment, cfg.Environment)
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		// wantErrors maps the YAML paths expected in the error to their sentinel errors.
+		wantErrors map[string]error
+	}{
+		{
+			name: "invalid values are reported with their paths",
+			content: `
+environment: "test"
+log_config: {level: "trace", format: "yaml"}
+shutdown: {drain_period: "-1s", timeout: "-1s"}
+startup: {warmup_period: "-30s", required_self_checks: -3}
+`,
+			wantErrors: map[string]error{
+				"log_config.level":             configkit.ErrInvalidValue,
+				"log_config.format":            configkit.ErrInvalidValue,
+				"shutdown.drain_period":        configkit.ErrNegative,
+				"shutdown.timeout":             configkit.ErrNegative,
+				"startup.warmup_period":        configkit.ErrNegative,
+				"startup.required_self_checks": configkit.ErrNegative,
+			},
+		},
+		{
+			name:    "pressure limits must not be negative",
+			content: validConfig + `pressure: {max_cpu_cores: -1, max_memory_mb: -64, max_duration: "-5m"}`,
+			wantErrors: map[string]error{
+				"pressure.max_cpu_cores": configkit.ErrNegative,
+				"pressure.max_memory_mb": configkit.ErrNegative,
+				"pressure.max_duration":  configkit.ErrNegative,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN: an invalid config
+			path := writeConfig(t, tt.content)
+
+			// WHEN: loading the config
+			cfg, err := config.Load(path)
+
+			// THEN: every problem is reported with its YAML path
+			testastic.Nil(t, cfg)
+
+			for fieldPath, wantErr := range tt.wantErrors {
+				testastic.ErrorIs(t, err, wantErr)
+				testastic.ErrorContains(t, err, "\n"+fieldPath+": ")
+			}
+		})
+	}
+
+	t.Run("unknown keys are rejected together with validation errors", func(t *testing.T) {
+		// GIVEN: a config with a misspelled key
+		path := writeConfig(t, `
+environment: "test"
+logging: {level: "info", format: "json"}
+shutdown: {timeout: "-1s"}
+`)
+
+		// WHEN: loading the config
+		_, err := config.Load(path)
+
+		// THEN: both the unknown key and the invalid field are reported
+		testastic.ErrorContains(t, err, "field logging not found")
+		testastic.ErrorContains(t, err, "shutdown.timeout: must not be negative")
+	})
+
+	t.Run("enabled admin API requires a password", func(t *testing.T) {
+		// GIVEN: a config enabling the admin API without ADMIN_PASSWORD
+		path := writeConfig(t, validConfig+`admin: {enabled: true}`)
+
+		// WHEN: loading the config
+		_, err := config.Load(path)
+
+		// THEN: the missing password is reported
+		testastic.ErrorIs(t, err, config.ErrAdminPasswordRequired)
+	})
+
+	t.Run("version is required", func(t *testing.T) {
+		t.Setenv("VERSION", "")
+
+		// GIVEN: a valid config without the VERSION environment variable
+		path := writeConfig(t, validConfig)
+
+		// WHEN: loading the config
+		_, err := config.Load(path)
+
+		// THEN: the missing version is reported
+		testastic.ErrorIs(t, err, config.ErrVersionRequired)
+	})
+}
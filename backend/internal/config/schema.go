@@ -0,0 +1,18 @@
+package config
+
+import (
+	"phasor/shared/configkit"
+	"reflect"
+)
+
+// schemaEnums maps YAML paths to their allowed values, shared with validate.
+var schemaEnums = map[string][]string{
+	"log_config.level":  logLevels,
+	"log_config.format": logFormats,
+}
+
+// JSONSchema returns the JSON Schema of the YAML config file, generated from Config.
+func JSONSchema() ([]byte, error) {
+	//nolint:wrapcheck // The error is already wrapped by configkit.
+	return configkit.JSONSchema(reflect.TypeFor[Config](), "phasor backend config", schemaEnums)
+}
@@ -0,0 +1,7 @@
+package config
+
+// Allowed values of enumerated fields, shared by validate and JSONSchema.
+var (
+	logLevels  = []string{"debug", "info", "warn", "error"}
+	logFormats = []string{"json", "text"}
+)
@@ -0,0 +1,75 @@
+// Package health provides health checkers for the backend service.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/monkescience/vital"
+)
+
+// Probe identifies a health probe whose result can be forced to fail.
+type Probe string
+
+const (
+	// ProbeLiveness is the liveness probe served at /health/live.
+	ProbeLiveness Probe = "liveness"
+	// ProbeReadiness is the readiness probe served at /health/ready.
+	ProbeReadiness Probe = "readiness"
+)
+
+// FaultChecker forces health probes to fail for a limited duration. It reports the readiness
+// fault as a readiness check; the liveness fault is queried via Failing.
+type FaultChecker struct {
+	mu        sync.Mutex
+	failUntil map[Probe]time.Time
+}
+
+// NewFaultChecker creates a new fault checker with no active faults.
+func NewFaultChecker() *FaultChecker {
+	return &FaultChecker{
+		failUntil: make(map[Probe]time.Time),
+	}
+}
+
+// Name returns the name of this health check.
+func (c *FaultChecker) Name() string {
+	return "fault"
+}
+
+// Check reports an error while a readiness fault is active.
+func (c *FaultChecker) Check(_ context.Context) (vital.Status, string) {
+	failing, until := c.Failing(ProbeReadiness)
+	if failing {
+		return vital.StatusError, fmt.Sprintf(
+			"readiness forced to fail for %s",
+			time.Until(until).Round(time.Second),
+		)
+	}
+
+	return vital.StatusOK, ""
+}
+
+// FailFor forces the probe to fail for the given duration and returns when the fault ends.
+// A zero duration clears an active fault.
+func (c *FaultChecker) FailFor(probe Probe, duration time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	until := time.Now().Add(duration)
+	c.failUntil[probe] = until
+
+	return until
+}
+
+// Failing reports whether the probe is currently forced to fail and until when.
+func (c *FaultChecker) Failing(probe Probe) (bool, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	until := c.failUntil[probe]
+
+	return time.Now().Before(until), until
+}
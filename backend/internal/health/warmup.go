@@ -0,0 +1,58 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/monkescience/vital"
+)
+
+// WarmupChecker simulates a slow-starting instance. It reports not ready until the warm-up
+// period has elapsed and then until the required number of self-checks have passed, where
+// each readiness check performed after the warm-up period counts as one passed self-check.
+type WarmupChecker struct {
+	mu                 sync.Mutex
+	readyAt            time.Time
+	requiredSelfChecks int
+	passedSelfChecks   int
+}
+
+// NewWarmupChecker creates a new warm-up checker starting now.
+func NewWarmupChecker(warmupPeriod time.Duration, requiredSelfChecks int) *WarmupChecker {
+	return &WarmupChecker{
+		readyAt:            time.Now().Add(warmupPeriod),
+		requiredSelfChecks: requiredSelfChecks,
+	}
+}
+
+// Name returns the name of this health check.
+func (c *WarmupChecker) Name() string {
+	return "warmup"
+}
+
+// Check reports an error with the reason while the instance is still warming up.
+func (c *WarmupChecker) Check(_ context.Context) (vital.Status, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	remaining := time.Until(c.readyAt)
+	if remaining > 0 {
+		return vital.StatusError, fmt.Sprintf("warming up: %s remaining", remaining.Round(time.Second))
+	}
+
+	if c.passedSelfChecks < c.requiredSelfChecks {
+		c.passedSelfChecks++
+	}
+
+	if c.passedSelfChecks < c.requiredSelfChecks {
+		return vital.StatusError, fmt.Sprintf(
+			"self-checks: %d/%d passed",
+			c.passedSelfChecks,
+			c.requiredSelfChecks,
+		)
+	}
+
+	return vital.StatusOK, ""
+}
@@ -0,0 +1,106 @@
+package instanceapi
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders lists canonical header names whose values are never echoed back.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+	"X-Csrf-Token":        true,
+}
+
+// GetInstanceEcho returns the request as received by the instance, including headers with
+// sensitive values redacted, the X-Forwarded-* chain, and the instance identity.
+func (h *InstanceHandler) GetInstanceEcho(writer http.ResponseWriter, req *http.Request) {
+	forwardedFor := forwardedForChain(req.Header)
+
+	response := EchoResponse{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        req.URL.Query(),
+		Headers:      redactHeaders(req.Header),
+		RemoteAddr:   req.RemoteAddr,
+		ClientIp:     clientIP(req.RemoteAddr, forwardedFor),
+		ForwardedFor: forwardedFor,
+		Instance: InstanceIdentity{
+			Hostname: h.getHostname(),
+			Version:  h.version,
+		},
+		ForwardedHost:  optionalHeader(req.Header, "X-Forwarded-Host"),
+		ForwardedProto: optionalHeader(req.Header, "X-Forwarded-Proto"),
+		ForwardedPort:  optionalHeader(req.Header, "X-Forwarded-Port"),
+	}
+
+	respondJSON(writer, response)
+}
+
+// redactHeaders copies the headers, replacing the values of sensitive headers.
+func redactHeaders(header http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(header))
+
+	for name, values := range header {
+		if !sensitiveHeaders[name] {
+			redacted[name] = values
+
+			continue
+		}
+
+		masked := make([]string, len(values))
+		for i := range values {
+			masked[i] = redactedValue
+		}
+
+		redacted[name] = masked
+	}
+
+	return redacted
+}
+
+// forwardedForChain returns all X-Forwarded-For entries in order, from the originating client to the last proxy.
+func forwardedForChain(header http.Header) []string {
+	chain := []string{}
+
+	for _, value := range header.Values("X-Forwarded-For") {
+		for entry := range strings.SplitSeq(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry != "" {
+				chain = append(chain, entry)
+			}
+		}
+	}
+
+	return chain
+}
+
+// clientIP returns the originating client IP from the X-Forwarded-For chain, or the peer IP.
+func clientIP(remoteAddr string, forwardedFor []string) string {
+	if len(forwardedFor) > 0 {
+		return forwardedFor[0]
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+
+	return host
+}
+
+func optionalHeader(header http.Header, name string) *string {
+	value := header.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
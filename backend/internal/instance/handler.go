@@ -3,6 +3,7 @@ package instanceapi
 import (
 	"encoding/json"
 	"net/http"
+	"phasor/backend/internal/pressure"
 	"runtime"
 	"time"
 )
@@ -10,36 +11,59 @@ import (
 // HostnameFunc is a function that returns the hostname.
 type HostnameFunc func() string
 
+// ClockFunc is a function that returns the current time.
+type ClockFunc func() time.Time
+
 // InstanceHandler handles instance information requests.
 type InstanceHandler struct {
-	version     string
-	getHostname HostnameFunc
-	startTime   time.Time
+	version        string
+	getHostname    HostnameFunc
+	now            ClockFunc
+	startTime      time.Time
+	simulator      *pressure.Simulator
+	pressureLimits PressureLimits
 }
 
-// NewInstanceHandler creates a new instance handler with the specified version and hostname function.
-func NewInstanceHandler(version string, getHostname HostnameFunc) *InstanceHandler {
+// NewInstanceHandler creates a new instance handler with the specified version, hostname function,
+// clock, and resource pressure simulator limited by pressureLimits. Uptime is measured from the
+// clock's time at creation.
+func NewInstanceHandler(
+	version string,
+	getHostname HostnameFunc,
+	now ClockFunc,
+	simulator *pressure.Simulator,
+	pressureLimits PressureLimits,
+) *InstanceHandler {
 	return &InstanceHandler{
-		version:     version,
-		getHostname: getHostname,
-		startTime:   time.Now(),
+		version:        version,
+		getHostname:    getHostname,
+		now:            now,
+		startTime:      now(),
+		simulator:      simulator,
+		pressureLimits: pressureLimits,
 	}
 }
 
 // GetInstanceInfo returns information about the running instance including version,
-// hostname, uptime, and Go version.
+// hostname, uptime, Go version, and active resource pressure.
 func (h *InstanceHandler) GetInstanceInfo(writer http.ResponseWriter, _ *http.Request) {
 	hostname := h.getHostname()
-	uptime := time.Since(h.startTime)
+	now := h.now()
 
 	response := InstanceInfoResponse{
 		Version:   h.version,
 		Hostname:  hostname,
-		Uptime:    uptime.String(),
+		Uptime:    now.Sub(h.startTime).String(),
 		GoVersion: runtime.Version(),
-		Timestamp: time.Now(),
+		Timestamp: now,
+		Pressure:  toPressureStatus(h.simulator.Status()),
 	}
 
+	respondJSON(writer, response)
+}
+
+// respondJSON writes the response as JSON with a 200 status code.
+func respondJSON(writer http.ResponseWriter, response any) {
 	writer.Header().Set("Content-Type", "application/json")
 
 	encodeErr := json.NewEncoder(writer).Encode(response)
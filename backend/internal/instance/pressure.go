@@ -0,0 +1,133 @@
+package instanceapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"phasor/backend/internal/pressure"
+	"time"
+
+	"github.com/monkescience/vital"
+)
+
+// PressureLimits caps the resource pressure a single request may create.
+type PressureLimits struct {
+	Enabled     bool
+	MaxCPUCores int
+	MaxMemoryMB int
+	MaxDuration time.Duration
+}
+
+// StartCpuPressure keeps the requested number of cores busy for the requested duration.
+func (h *InstanceHandler) StartCpuPressure(writer http.ResponseWriter, req *http.Request) {
+	if !h.pressureLimits.Enabled {
+		vital.RespondProblem(writer, pressureDisabled())
+
+		return
+	}
+
+	var body CpuPressureRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		vital.RespondProblem(writer, vital.BadRequest(fmt.Sprintf("invalid request body: %v", err)))
+
+		return
+	}
+
+	if body.Cores < 1 || body.Cores > h.pressureLimits.MaxCPUCores {
+		vital.RespondProblem(writer, vital.BadRequest(
+			fmt.Sprintf("cores must be between 1 and %d", h.pressureLimits.MaxCPUCores),
+		))
+
+		return
+	}
+
+	duration, problem := h.parseDuration(body.Duration)
+	if problem != nil {
+		vital.RespondProblem(writer, problem)
+
+		return
+	}
+
+	respondJSON(writer, toPressureStatus(h.simulator.BurnCPU(body.Cores, duration)))
+}
+
+// StartMemoryPressure allocates and retains the requested amount of memory for the requested duration.
+func (h *InstanceHandler) StartMemoryPressure(writer http.ResponseWriter, req *http.Request) {
+	if !h.pressureLimits.Enabled {
+		vital.RespondProblem(writer, pressureDisabled())
+
+		return
+	}
+
+	var body MemoryPressureRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		vital.RespondProblem(writer, vital.BadRequest(fmt.Sprintf("invalid request body: %v", err)))
+
+		return
+	}
+
+	if body.Megabytes < 1 || body.Megabytes > h.pressureLimits.MaxMemoryMB {
+		vital.RespondProblem(writer, vital.BadRequest(
+			fmt.Sprintf("megabytes must be between 1 and %d", h.pressureLimits.MaxMemoryMB),
+		))
+
+		return
+	}
+
+	duration, problem := h.parseDuration(body.Duration)
+	if problem != nil {
+		vital.RespondProblem(writer, problem)
+
+		return
+	}
+
+	respondJSON(writer, toPressureStatus(h.simulator.RetainMemory(body.Megabytes, duration)))
+}
+
+// StopPressure stops CPU pressure and releases retained memory.
+func (h *InstanceHandler) StopPressure(writer http.ResponseWriter, _ *http.Request) {
+	if !h.pressureLimits.Enabled {
+		vital.RespondProblem(writer, pressureDisabled())
+
+		return
+	}
+
+	respondJSON(writer, toPressureStatus(h.simulator.Release()))
+}
+
+// parseDuration parses a request duration and checks it against the configured maximum.
+func (h *InstanceHandler) parseDuration(value string) (time.Duration, *vital.ProblemDetail) {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 || duration > h.pressureLimits.MaxDuration {
+		return 0, vital.BadRequest(
+			fmt.Sprintf("duration must be a positive duration of at most %s", h.pressureLimits.MaxDuration),
+		)
+	}
+
+	return duration, nil
+}
+
+func pressureDisabled() *vital.ProblemDetail {
+	return vital.Forbidden("resource pressure simulation is disabled")
+}
+
+func toPressureStatus(status pressure.Status) PressureStatus {
+	response := PressureStatus{
+		CpuCores:    status.CPUCores,
+		MemoryBytes: status.MemoryBytes,
+	}
+
+	if !status.CPUUntil.IsZero() {
+		response.CpuUntil = &status.CPUUntil
+	}
+
+	if !status.MemoryUntil.IsZero() {
+		response.MemoryUntil = &status.MemoryUntil
+	}
+
+	return response
+}
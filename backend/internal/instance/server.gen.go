@@ -18,13 +18,66 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// CpuPressureRequest defines model for cpu_pressure_request.
+type CpuPressureRequest struct {
+	// Cores Number of cores to keep busy
+	Cores int `json:"cores"`
+
+	// Duration How long to keep the cores busy
+	Duration string `json:"duration"`
+}
+
+// EchoResponse defines model for echo_response.
+type EchoResponse struct {
+	// ClientIp Originating client IP, the first X-Forwarded-For entry or the peer IP
+	ClientIp string `json:"client_ip"`
+
+	// ForwardedFor X-Forwarded-For chain from the originating client to the last proxy
+	ForwardedFor []string `json:"forwarded_for"`
+
+	// ForwardedHost Value of the X-Forwarded-Host header
+	ForwardedHost *string `json:"forwarded_host,omitempty"`
+
+	// ForwardedPort Value of the X-Forwarded-Port header
+	ForwardedPort *string `json:"forwarded_port,omitempty"`
+
+	// ForwardedProto Value of the X-Forwarded-Proto header
+	ForwardedProto *string `json:"forwarded_proto,omitempty"`
+
+	// Headers Request headers by canonical name, with sensitive values redacted
+	Headers  map[string][]string `json:"headers"`
+	Instance InstanceIdentity    `json:"instance"`
+
+	// Method HTTP method of the request
+	Method string `json:"method"`
+
+	// Path Request path as received by the instance
+	Path string `json:"path"`
+
+	// Query Query parameters by name
+	Query map[string][]string `json:"query"`
+
+	// RemoteAddr Network address of the immediate peer, usually the last proxy
+	RemoteAddr string `json:"remote_addr"`
+}
+
+// InstanceIdentity defines model for instance_identity.
+type InstanceIdentity struct {
+	// Hostname Instance hostname
+	Hostname string `json:"hostname"`
+
+	// Version Application version
+	Version string `json:"version"`
+}
+
 // InstanceInfoResponse defines model for instance_info_response.
 type InstanceInfoResponse struct {
 	// GoVersion Go runtime version
 	GoVersion string `json:"go_version"`
 
 	// Hostname Instance hostname
-	Hostname string `json:"hostname"`
+	Hostname string         `json:"hostname"`
+	Pressure PressureStatus `json:"pressure"`
 
 	// Timestamp Current server timestamp
 	Timestamp time.Time `json:"timestamp"`
@@ -36,23 +89,113 @@ type InstanceInfoResponse struct {
 	Version string `json:"version"`
 }
 
+// MemoryPressureRequest defines model for memory_pressure_request.
+type MemoryPressureRequest struct {
+	// Duration How long to retain the memory
+	Duration string `json:"duration"`
+
+	// Megabytes Amount of memory to allocate and retain in megabytes
+	Megabytes int `json:"megabytes"`
+}
+
+// PressureStatus defines model for pressure_status.
+type PressureStatus struct {
+	// CpuCores Number of cores currently kept busy
+	CpuCores int `json:"cpu_cores"`
+
+	// CpuUntil When the CPU pressure ends, omitted when inactive
+	CpuUntil *time.Time `json:"cpu_until,omitempty"`
+
+	// MemoryBytes Amount of memory currently retained in bytes
+	MemoryBytes int64 `json:"memory_bytes"`
+
+	// MemoryUntil When the retained memory is released, omitted when inactive
+	MemoryUntil *time.Time `json:"memory_until,omitempty"`
+}
+
+// ProblemDetail RFC 9457 problem details
+type ProblemDetail struct {
+	// Detail Explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance URI reference identifying this occurrence
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type *string `json:"type,omitempty"`
+}
+
+// BadRequest RFC 9457 problem details
+type BadRequest = ProblemDetail
+
+// PressureDisabled RFC 9457 problem details
+type PressureDisabled = ProblemDetail
+
+// StartCpuPressureJSONRequestBody defines body for StartCpuPressure for application/json ContentType.
+type StartCpuPressureJSONRequestBody = CpuPressureRequest
+
+// StartMemoryPressureJSONRequestBody defines body for StartMemoryPressure for application/json ContentType.
+type StartMemoryPressureJSONRequestBody = MemoryPressureRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Echo the received request
+	// (GET /instance/echo)
+	GetInstanceEcho(w http.ResponseWriter, r *http.Request)
 	// Get instance information
 	// (GET /instance/info)
 	GetInstanceInfo(w http.ResponseWriter, r *http.Request)
+	// Stop resource pressure
+	// (DELETE /instance/pressure)
+	StopPressure(w http.ResponseWriter, r *http.Request)
+	// Start CPU pressure
+	// (POST /instance/pressure/cpu)
+	StartCpuPressure(w http.ResponseWriter, r *http.Request)
+	// Start memory pressure
+	// (POST /instance/pressure/memory)
+	StartMemoryPressure(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Echo the received request
+// (GET /instance/echo)
+func (_ Unimplemented) GetInstanceEcho(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get instance information
 // (GET /instance/info)
 func (_ Unimplemented) GetInstanceInfo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Stop resource pressure
+// (DELETE /instance/pressure)
+func (_ Unimplemented) StopPressure(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start CPU pressure
+// (POST /instance/pressure/cpu)
+func (_ Unimplemented) StartCpuPressure(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start memory pressure
+// (POST /instance/pressure/memory)
+func (_ Unimplemented) StartMemoryPressure(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -62,6 +205,20 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetInstanceEcho operation middleware
+func (siw *ServerInterfaceWrapper) GetInstanceEcho(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInstanceEcho(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetInstanceInfo operation middleware
 func (siw *ServerInterfaceWrapper) GetInstanceInfo(w http.ResponseWriter, r *http.Request) {
 
@@ -76,6 +233,48 @@ func (siw *ServerInterfaceWrapper) GetInstanceInfo(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// StopPressure operation middleware
+func (siw *ServerInterfaceWrapper) StopPressure(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StopPressure(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartCpuPressure operation middleware
+func (siw *ServerInterfaceWrapper) StartCpuPressure(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartCpuPressure(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartMemoryPressure operation middleware
+func (siw *ServerInterfaceWrapper) StartMemoryPressure(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartMemoryPressure(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -189,9 +388,21 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/instance/echo", wrapper.GetInstanceEcho)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/instance/info", wrapper.GetInstanceInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/instance/pressure", wrapper.StopPressure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/instance/pressure/cpu", wrapper.StartCpuPressure)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/instance/pressure/memory", wrapper.StartMemoryPressure)
+	})
 
 	return r
 }
@@ -199,16 +410,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/3RTTW/bMAz9Kwa3o+OvLBvgW7HDllux7bShKFSZSVRYH5OoYkHg/z7QdhI7bk62JJKP",
-	"fHzvBNJqZw0aClCfIMgDatH/KhNIGInPyuzss8fgrAnIL6JpFClrRPvorUNPCgPUO9EGTMFNrk6wt89v",
-	"6IOyhk8NBumVo/4I32zioyGlMTnHpID/hHYtJ/+BvS2zqswKeEqBjg6hhkBemT10KRxsICM0Lutux8aT",
-	"S8i8bED/hn5VlNl4m0mrGWNnvRYENUwSF7jcbyCh3RL4a/QeDSUDQHKNnONXRbVZFeWq3Pwqq3r9qd58",
-	"/j2DbwThirPfw4+uf1mAf49amJVH0YiXFhPnrcQQkjF83sGX6lBu9Hod5rDRCxq2sEC9u8QH51ol+7w7",
-	"Wyyz4t0Vdil4/BuVx4bDrskT9i/dT3Q0XcG1qn15RUnQcVkWLHdKilqcCuLhcQuTWaDIWF1dCtahEU5B",
-	"DeuszEpIwQk69ArOzz7Iz2X3SEsefiBFb0LCQcwn8yFebKSEDpjIURnnWtBDDnRvG/YC0rnLLcMwN4Ph",
-	"+iaqouCPtIbQ9Ojiynv+GobNDOblv48ed1DDh/zq7ny0dn7H1z1zd4w0HSpEycLaxbY9Jr6fGpt+myFq",
-	"LfxxmOYy6jR5ABnswdq4JfHR2ybKUYPRt2xFIhfqPBdOzezapbfJP0nsWVi3mWG4zxYVnrr/AQAA///+",
-	"ABzJ/gQAAA==",
+	"H4sIAAAAAAAC/8xZbXMiuRH+Kyol3zLGYLD3wjfH59tzpe5CNr7kKlsUJUYNo9sZSSe17CUu/nuq5wXm",
+	"DTCXc3bLX4CR+uV5Wj1Pyy88Npk1GjR6Pn3hDrw12kP+ZSnkwsGvATzS19hoBJ1/FNamKhaojL60zixT",
+	"yP70izeanvk4gUzQpz86WPEp/8Pl3sdl8dRXuxYSUKiUb7fbiEvwsVOWrPIpf0yAld6Z8kzpJ5EqyYxj",
+	"8DkGkJ5hAiw2eqXWwYFkqcoUer6NuHXgfXCwkMqLZQryC4T/AbwJLgZWBcO8ykKaO6V8dqHR1tJsHqYN",
+	"i138NfSFlIr2inTmjAWHikhaidQDJbz/iTJ1xYdmRD+GbAmOmRXLFzA07BOAZcvgNzzi8FlkNqWNH0fz",
+	"iGdKqyxkfDqKOG4s8ClXGmENjhCWwYnCbNvL9+aZpUavd+YLlshh1xG/yvg84ivjMoF8uje78+nRKb3O",
+	"QSIwlCMyP5Yp1uKY73aY5S8QIwUJcWIWVUWfi2GqQONC2W6Gf3NqrbRApdesWMYeZlGe50o5j+zni++M",
+	"exZOgqRPDDS6DRUuLbEAjj3M2jgMx4PhYDQaD97xeSf5HKHC4GJlXDektsc4EUqzlTNZ7tN0I0aTP0mF",
+	"R2ad+dwiphlRxEfDAf1d8/k84gohy0HqhFn+IJwTm2bYifHYjfufIg1AFUmx1JP43nhkCQgJroWUTYQ3",
+	"blD+NIhNdgIwa9w5nmfGHfA8mYxPuXIGzTm+aH2/swTR+l53xXJ/uJ5fziCo3bOKdlu6YMsNi4U2WsUi",
+	"ZVpkELFnhQnzoL1C9QTsiVLzzIEUMYJsJvHCbwMmxqn/lL3iI//44f7b27vH+2/nlNvPF3dCC7ehJyJ9",
+	"FhvP59u+k6y0R6FjONWWq3ULJUGjwjzHDDAxsqdTPT7OWPGwIqfqt00u3t8/9jJhBSZdsxWI9JQJwiYG",
+	"9QSS0CQfu1yaTnaxX1Lb6vX3awC3eQve/06GmRVOZIAl8UR3m04Jy7CmYNEFOECVg8wgLISUPX3qR8Bn",
+	"4z4xegreV7CrLAOpBBbdMWLBB5Gmm6MdqmpJo6vp9ehqPOlBrPXOKOug5K2Cc3+gmrFHtVdAu//WCvJY",
+	"te6r8Lx3D/XKHP4Ofg+lZbZb0sTEg3sCdzEctfvj7g1b29gpkidwvvedfrvXS6xa1CKDuDhNQc17Zec4",
+	"gHr1m9/ga7M4mNB7w1zQqDI4kM/ajAZXo96Uoi/GTyULT4vTUj56FBhyRUypehRZj5y5C86BRlbExvYr",
+	"2wrl6vpiOLoYXT+OrqbjyfT65t9N7SYQLlD1hx5s/qTbg0Mm9IUDIUkM0zmPwXtWLm9G8O4qGV1n47F/",
+	"pWT8fxT0fnONuF30tRKsU1Bjsq/6M8iM2/yvQ8DrFLoDJKVIjbZw+1v1OYW9FssN9k0et5kJGqnbF07I",
+	"s0hTE1PLF1pWYSjN9lYagdxMTowknV6/N3N0RmiflTOnBBsWr5y24uKYpRv2CSweGLq6gxZ5oE6Vdj38",
+	"K4GCubvZT/spE7T0ETOZQgTJnmmN0iImtXb8RN+cd6LLKn0t4/v0C65BEtt9TL8bDb/55mZSD0RpvJnw",
+	"PnjKKE4htPNZRqM8c5CC8CDfHqv26LqrmRaI/eXZuGfois3v7tifJ9fvWLmQFQs977SDAwbuP9tU6KIT",
+	"eguxWqm4GA6VZyYuaIt7K6AuyptGf/rwwBysIN/KChW02tD0+Qqz+4PYI9eLhyw2EnrrARWmPQH9IzEO",
+	"mQ9ZJmgSL1RnBVluJTqkmc/J7ZTNViUUwe4y7vK/zVFe5SNlmdleYtzOHmoyasqHA9Ir24gbC1pYxad8",
+	"PBgNRqXgzRFtDRmkkgD7RhgMTvv6SHRskulMhdX8WBsLqRbzKnuQpL8AqzzuKZCoefF4NRweubE776au",
+	"eQnUe1FXZlVl6kMcg/erQCMIba8u6YryoUMTJ6YEp7k3X7cHueLuKMhKF02ETqBYmoC55bJf1qfFgxA+",
+	"6NWbQnhAjvdguSvOelINPF2edQfR97BPtb65BWhdAUtIAfvOOhrrm+/EQmLk/d633wUdYGn/rPLzhqh2",
+	"VPqr7pDRWEvoRXwyHB9ysYv5snsT3sSdkmWu7eYA6pexDeTS9t7l/RXAFi1jrZ5AM90SQKR42Mo4Jlil",
+	"yCLmwKYipu4p9IYVr94Gdz30CId3NjQoyg/fX4zc/G7s9F7Db5sdHF2A7ZetkEaZe4KmKo7h6eKo/3/n",
+	"9yso4bDJ4IFiKo/fwXq6LQcEX5sQfCXgXlVH5drjpfRDvuiNq+nQSPf1FdQPTcy+oppqs5kHX1xaVLeT",
+	"9URmzsgQ518iHlzKp8Wd+vTyUljVuH/ZRt13iFiTYmvv9MXvg46F+fa/AwCknwpLSh0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,162 @@
+// Package pressure simulates CPU and memory load to demo horizontal pod autoscaling.
+package pressure
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+const (
+	bytesPerMegabyte = 1 << 20
+	pageSize         = 4096
+)
+
+// Status describes the currently active resource pressure.
+type Status struct {
+	CPUCores    int
+	CPUUntil    time.Time
+	MemoryBytes int64
+	MemoryUntil time.Time
+}
+
+// Simulator burns CPU and retains memory on request. Starting a new CPU or memory
+// simulation replaces the previous one of the same kind.
+type Simulator struct {
+	now         func() time.Time
+	mu          sync.Mutex
+	status      Status
+	cpuRun      int
+	stopCPU     context.CancelFunc
+	memoryRun   int
+	memory      []byte
+	memoryTimer *time.Timer
+}
+
+// NewSimulator creates a new idle simulator that reports the end of simulations relative to now.
+func NewSimulator(now func() time.Time) *Simulator {
+	return &Simulator{now: now}
+}
+
+// BurnCPU keeps the given number of cores busy for the duration.
+func (s *Simulator) BurnCPU(cores int, duration time.Duration) Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopCPULocked()
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	s.cpuRun++
+	s.stopCPU = cancel
+	s.status.CPUCores = cores
+	s.status.CPUUntil = s.now().Add(duration)
+
+	for range cores {
+		go burn(ctx)
+	}
+
+	run := s.cpuRun
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Only reset the status if this simulation has not been replaced in the meantime.
+		if s.cpuRun == run {
+			s.stopCPULocked()
+		}
+	}()
+
+	return s.status
+}
+
+// RetainMemory allocates and holds the given number of megabytes for the duration.
+func (s *Simulator) RetainMemory(megabytes int, duration time.Duration) Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.releaseMemoryLocked()
+
+	memory := make([]byte, int64(megabytes)*bytesPerMegabyte)
+	// Touch every page so the memory is actually resident and shows up in container metrics.
+	for i := 0; i < len(memory); i += pageSize {
+		memory[i] = 1
+	}
+
+	s.memoryRun++
+	s.memory = memory
+	s.status.MemoryBytes = int64(len(memory))
+	s.status.MemoryUntil = s.now().Add(duration)
+
+	run := s.memoryRun
+	s.memoryTimer = time.AfterFunc(duration, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Only release if this allocation has not been replaced in the meantime.
+		if s.memoryRun == run {
+			s.releaseMemoryLocked()
+		}
+	})
+
+	return s.status
+}
+
+// Release stops all active simulations and frees retained memory.
+func (s *Simulator) Release() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopCPULocked()
+	s.releaseMemoryLocked()
+
+	return s.status
+}
+
+// Status returns the currently active resource pressure.
+func (s *Simulator) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.status
+}
+
+func (s *Simulator) stopCPULocked() {
+	if s.stopCPU != nil {
+		s.stopCPU()
+		s.stopCPU = nil
+	}
+
+	s.status.CPUCores = 0
+	s.status.CPUUntil = time.Time{}
+}
+
+func (s *Simulator) releaseMemoryLocked() {
+	if s.memoryTimer != nil {
+		s.memoryTimer.Stop()
+		s.memoryTimer = nil
+	}
+
+	if s.memory != nil {
+		s.memory = nil
+
+		debug.FreeOSMemory()
+	}
+
+	s.status.MemoryBytes = 0
+	s.status.MemoryUntil = time.Time{}
+}
+
+// burn keeps one core busy until the context is done.
+func burn(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+	}
+}
@@ -6,4 +6,4 @@ import (
 	_ "github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen"
 )
 
-//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=../openapi/instance-api.oapi-codegen.server.yaml ../openapi/instance-api.yaml
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=../../openapi/instance-api.oapi-codegen.server.yaml ../../openapi/instance-api.yaml
@@ -0,0 +1,103 @@
+package testutil
+
+import (
+	"log/slog"
+	"phasor/backend/internal/config"
+	"time"
+)
+
+// Option configures a test server created by NewTestServer.
+type Option func(*settings)
+
+// settings holds everything an Option may change about a test server.
+type settings struct {
+	cfg      *config.Config
+	hostname string
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+func newSettings(opts []Option) *settings {
+	s := &settings{
+		cfg: &config.Config{
+			Version:     testVersion,
+			Environment: "test",
+		},
+		hostname: testHostname,
+		now:      time.Now,
+		logger:   slog.New(slog.DiscardHandler),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithVersion sets the version the server reports, "test-version" by default.
+func WithVersion(version string) Option {
+	return func(s *settings) {
+		s.cfg.Version = version
+	}
+}
+
+// WithHostname sets the hostname the server reports, "test-host" by default, e.g. a pod name
+// containing a rollout's pod template hash.
+func WithHostname(hostname string) Option {
+	return func(s *settings) {
+		s.hostname = hostname
+	}
+}
+
+// WithEnvironment sets the environment the health endpoints report, "test" by default.
+func WithEnvironment(environment string) Option {
+	return func(s *settings) {
+		s.cfg.Environment = environment
+	}
+}
+
+// WithClock makes the server read the current time from now, e.g. Clock.Now, so timestamps
+// and uptimes can be asserted exactly.
+func WithClock(now func() time.Time) Option {
+	return func(s *settings) {
+		s.now = now
+	}
+}
+
+// WithFaults enables the admin API, protected by the given basic auth credentials, so tests
+// can make liveness or readiness fail.
+func WithFaults(username, password string) Option {
+	return func(s *settings) {
+		s.cfg.Admin.Enabled = true
+		s.cfg.Admin.Username = username
+		s.cfg.Admin.Password = password
+	}
+}
+
+// WithStartup simulates a slow start: the server stays not ready for warmupPeriod and then
+// until requiredSelfChecks have passed.
+func WithStartup(warmupPeriod time.Duration, requiredSelfChecks int) Option {
+	return func(s *settings) {
+		s.cfg.Startup.WarmupPeriod = warmupPeriod
+		s.cfg.Startup.RequiredSelfChecks = requiredSelfChecks
+	}
+}
+
+// WithPressure enables the resource pressure endpoints, limited to maxCPUCores, maxMemoryMB
+// and maxDuration.
+func WithPressure(maxCPUCores, maxMemoryMB int, maxDuration time.Duration) Option {
+	return func(s *settings) {
+		s.cfg.Pressure.Enabled = true
+		s.cfg.Pressure.MaxCPUCores = maxCPUCores
+		s.cfg.Pressure.MaxMemoryMB = maxMemoryMB
+		s.cfg.Pressure.MaxDuration = maxDuration
+	}
+}
+
+// WithLogger sets the logger of the server, which discards all logs by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *settings) {
+		s.logger = logger
+	}
+}
@@ -5,19 +5,58 @@ import (
 	"log/slog"
 	"net/http/httptest"
 	"phasor/backend/internal/app"
-	"phasor/backend/internal/config"
+	"phasor/shared/lifecycle"
+	"time"
+
+	"github.com/monkescience/vital"
+
+	sharedtestutil "phasor/shared/testutil"
+)
+
+const (
+	testShutdownTimeout = 5 * time.Second
+	testVersion         = "test-version"
+	testHostname        = "test-host"
 )
 
+// Server is a test server that can be shut down using the production drain sequence.
+type Server struct {
+	*httptest.Server
+
+	server          *vital.Server
+	shutdownChecker *lifecycle.ShutdownChecker
+	logger          *slog.Logger
+}
+
 // NewTestServer creates a fully configured test server with the same middleware
-// and routing as production. Returns an httptest.Server ready for integration tests.
-// Uses a fixed hostname "test-host" for deterministic test output.
-func NewTestServer(version string, logger *slog.Logger) *httptest.Server {
-	cfg := &config.Config{
-		Version:     version,
-		Environment: "test",
-	}
+// and routing as production. Returns a Server ready for integration tests.
+// Without options it reports version "test-version" and the fixed hostname "test-host"
+// for deterministic test output.
+func NewTestServer(opts ...Option) *Server {
+	s := newSettings(opts)
+	shutdownChecker := lifecycle.NewShutdownChecker()
+	hostname := s.hostname
+	router := app.SetupRouterWithHostnameAndClock(
+		s.cfg,
+		s.logger,
+		shutdownChecker,
+		func() string { return hostname },
+		s.now,
+	)
+
+	server := vital.NewServer(router, vital.WithShutdownTimeout(testShutdownTimeout), vital.WithLogger(s.logger))
 
-	router := app.SetupRouterWithHostname(cfg, logger, func() string { return "test-host" })
+	return &Server{
+		Server:          sharedtestutil.StartServer(server),
+		server:          server,
+		shutdownChecker: shutdownChecker,
+		logger:          s.logger,
+	}
+}
 
-	return httptest.NewServer(router)
+// Shutdown runs the production graceful shutdown sequence: readiness starts failing,
+// the server keeps serving for drainPeriod, and is then shut down.
+func (s *Server) Shutdown(drainPeriod time.Duration) error {
+	//nolint:wrapcheck // The error is already wrapped by Drain.
+	return lifecycle.Drain(s.server, s.shutdownChecker, drainPeriod, s.logger)
 }
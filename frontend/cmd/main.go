@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
-	"path/filepath"
+	"os"
 	"phasor/frontend/internal/app"
 	"phasor/frontend/internal/config"
+	"phasor/shared/lifecycle"
 
 	"github.com/monkescience/vital"
 )
@@ -13,10 +16,21 @@ import (
 const serverPort = 8081
 
 func main() {
-	configPath := flag.String("config", "/config/config.yaml", "Path to the configuration file")
+	configPath := flag.String("config", "", "Path to the configuration file (default: built-in defaults only)")
+
+	flag.Usage = usage
 
 	flag.Parse()
 
+	if flag.NArg() > 0 {
+		err := app.RunCommand(flag.Arg(0), flag.Args()[1:], *configPath, os.Stdout)
+		if err != nil {
+			log.Fatalf("%s: %v", flag.Arg(0), err)
+		}
+
+		return
+	}
+
 	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
@@ -31,12 +45,34 @@ func main() {
 		log.Fatalf("failed to setup logger: %v", err)
 	}
 
-	templatesPath := filepath.Join("frontend", "internal", "frontend", "templates")
+	shutdownChecker := lifecycle.NewShutdownChecker()
 
-	router, err := app.SetupRouter(cfg, templatesPath, logger)
+	router, err := app.SetupRouter(context.Background(), cfg, shutdownChecker, logger)
 	if err != nil {
 		log.Fatalf("failed to setup router: %v", err)
 	}
 
-	vital.NewServer(router, vital.WithPort(serverPort), vital.WithLogger(logger)).Run()
+	server := vital.NewServer(
+		router,
+		vital.WithPort(serverPort),
+		vital.WithShutdownTimeout(*cfg.Shutdown.Timeout),
+		vital.WithLogger(logger),
+	)
+
+	err = lifecycle.Run(server, shutdownChecker, *cfg.Shutdown.DrainPeriod, logger)
+	if err != nil {
+		log.Fatalf("failed to run server: %v", err)
+	}
+}
+
+func usage() {
+	output := flag.CommandLine.Output()
+
+	fmt.Fprintf(output, "Usage: %s [-config path] [command]\n\n", os.Args[0])
+	fmt.Fprintln(output, "Without a command the server is started. Commands:")
+	fmt.Fprintln(output, "  validate         Load and validate the config file")
+	fmt.Fprintln(output, "  print-effective  Print the config with defaults and environment variables applied")
+	fmt.Fprintln(output, "  schema           Print the JSON Schema of the config file")
+	fmt.Fprintln(output, "\nFlags:")
+	flag.PrintDefaults()
 }
@@ -2,11 +2,16 @@ module phasor/frontend
 
 go 1.25.5
 
+replace phasor/shared => ../shared
+
 require (
 	github.com/go-chi/chi/v5 v5.2.3
+	github.com/monkescience/testastic v0.0.0-20251216213937-22bb94593d66
 	github.com/monkescience/vital v0.0.0-20251223172315-8503480c42fe
 	github.com/oapi-codegen/oapi-codegen/v2 v2.5.1
+	golang.org/x/mod v0.30.0
 	gopkg.in/yaml.v3 v3.0.1
+	phasor/shared v0.0.0
 )
 
 require (
@@ -26,10 +31,10 @@ require (
 	github.com/vmware-labs/yaml-jsonpath v0.3.2 // indirect
 	github.com/woodsbury/decimal128 v1.4.0 // indirect
 	go.yaml.in/yaml/v4 v4.0.0-rc.3 // indirect
-	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/term v0.38.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
 	golang.org/x/tools v0.39.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20201130134442-10cb98267c6c // indirect
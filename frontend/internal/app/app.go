@@ -1,47 +1,242 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"phasor/frontend/internal/config"
 	"phasor/frontend/internal/frontend"
 	"phasor/frontend/internal/health"
+	"phasor/frontend/internal/rollout"
+	"phasor/frontend/internal/security"
+	"phasor/shared/lifecycle"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/monkescience/vital"
 )
 
 // SetupRouter creates and configures the application router with all middleware and handlers.
-func SetupRouter(cfg *config.Config, templatesPath string, logger *slog.Logger) (*chi.Mux, error) {
+// The shutdown checker is registered as a readiness check so that draining fails readiness.
+// Every backend target is probed in the background until ctx is canceled.
+func SetupRouter(
+	ctx context.Context,
+	cfg *config.Config,
+	shutdownChecker *lifecycle.ShutdownChecker,
+	logger *slog.Logger,
+) (*chi.Mux, error) {
+	return SetupRouterWithHostnameAndClock(ctx, cfg, shutdownChecker, logger, systemHostname, time.Now)
+}
+
+// SetupRouterWithHostnameAndClock creates and configures the application router with a custom
+// hostname function and clock. This is primarily useful for testing with deterministic hostnames,
+// timestamps and uptimes.
+func SetupRouterWithHostnameAndClock(
+	ctx context.Context,
+	cfg *config.Config,
+	shutdownChecker *lifecycle.ShutdownChecker,
+	logger *slog.Logger,
+	getHostname frontend.HostnameFunc,
+	now frontend.ClockFunc,
+) (*chi.Mux, error) {
 	router := chi.NewRouter()
 	router.Use(vital.Recovery(logger))
+	router.Use(security.Headers(securityConfig(cfg.SecurityHeaders)))
 
-	backendChecker, err := health.NewBackendChecker(cfg.BackendURL)
+	targets := cfg.BackendTargets()
+
+	backendCheckers, err := newBackendCheckers(ctx, targets, cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create backend health checker: %w", err)
+		return nil, err
 	}
 
 	healthHandler := vital.NewHealthHandler(
+		vital.WithVersion(cfg.Version),
 		vital.WithEnvironment(cfg.Environment),
-		vital.WithCheckers(backendChecker),
+		vital.WithCheckers(append([]vital.Checker{shutdownChecker}, backendCheckers...)...),
 	)
 	router.Mount("/health", healthHandler)
 
-	frontendHandler, err := frontend.NewFrontendHandler(
-		templatesPath,
-		cfg.BackendURL,
-		cfg.TileColors,
+	rolloutSource, err := newRolloutStatusSource(cfg, now)
+	if err != nil {
+		return nil, err
+	}
+
+	staticAssets, err := frontend.NewStaticAssets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create static assets: %w", err)
+	}
+
+	templates, err := newTemplates(cfg.TemplatesDir, staticAssets)
+	if err != nil {
+		return nil, err
+	}
+
+	frontendHandler := frontend.NewFrontendHandler(
+		templates,
+		cfg.Version,
+		backendTargets(targets),
+		tileColors(cfg),
+		headerProfiles(cfg.HeaderProfiles),
+		rolloutSource,
+		now,
 	)
+
+	configChecksum, err := cfg.Checksum()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create frontend handler: %w", err)
+		return nil, fmt.Errorf("failed to compute config checksum: %w", err)
+	}
+
+	var previewHandler *frontend.PreviewHandler
+	if cfg.Preview.ActiveURL != "" {
+		previewHandler = frontend.NewPreviewHandler(templates, cfg.Preview.ActiveURL, cfg.Preview.PreviewURL)
 	}
 
+	router.Handle(frontend.StaticPrefix+"*", staticAssets)
+
 	router.Group(func(r chi.Router) {
 		r.Use(vital.TraceContext())
 		r.Use(vital.RequestLogger(logger))
 		r.Get("/", frontendHandler.IndexHandler)
 		r.Get("/tiles", frontendHandler.TilesHandler)
+
+		infoHandler := frontend.NewInfoHandler(cfg.Version, configChecksum, getHostname, now)
+		r.Get("/frontend/info", infoHandler.GetFrontendInfo)
+
+		if previewHandler != nil {
+			r.Get("/preview", previewHandler.PreviewPageHandler)
+		}
 	})
 
 	return router, nil
 }
+
+// newTemplates returns the embedded templates, or the templates in dir when it is set.
+func newTemplates(dir string, staticAssets *frontend.StaticAssets) (*frontend.Templates, error) {
+	var (
+		templates *frontend.Templates
+		err       error
+	)
+
+	if dir == "" {
+		templates, err = frontend.NewEmbeddedTemplates(staticAssets)
+	} else {
+		templates, err = frontend.NewDirTemplates(dir, staticAssets)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// securityConfig converts the configured security headers to the middleware configuration.
+func securityConfig(headers config.SecurityHeaders) security.Config {
+	return security.Config{
+		ContentSecurityPolicy:   headers.ContentSecurityPolicy,
+		StrictTransportSecurity: headers.StrictTransportSecurity,
+		FrameOptions:            headers.FrameOptions,
+		ReferrerPolicy:          headers.ReferrerPolicy,
+		PermissionsPolicy:       headers.PermissionsPolicy,
+	}
+}
+
+// systemHostname returns the system hostname or "unknown" if it cannot be determined.
+func systemHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+
+	return hostname
+}
+
+// newBackendCheckers creates and starts a readiness checker per target. A single target is
+// reported as "backend", multiple targets as "backend-<name>".
+func newBackendCheckers(
+	ctx context.Context,
+	targets []config.Target,
+	cfg *config.Config,
+	logger *slog.Logger,
+) ([]vital.Checker, error) {
+	checkerCfg := health.BackendCheckerConfig{
+		Interval:         cfg.BackendHealth.Interval,
+		FailureThreshold: cfg.BackendHealth.FailureThreshold,
+		Policy:           health.BackendPolicy(cfg.BackendHealth.Policy),
+	}
+
+	checkers := make([]vital.Checker, len(targets))
+	for i, target := range targets {
+		name := "backend"
+		if len(targets) > 1 {
+			name = "backend-" + target.Name
+		}
+
+		checker, err := health.NewBackendChecker(name, target.URL, target.HealthURL, checkerCfg, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create backend health checker for %s: %w", target.Name, err)
+		}
+
+		checker.Start(ctx)
+		checkers[i] = checker
+	}
+
+	return checkers, nil
+}
+
+// newRolloutStatusSource creates the configured rollout status source, or nil if none is configured.
+// The Kubernetes status is cached, so /tiles does not call the API server on every request.
+func newRolloutStatusSource(cfg *config.Config, now frontend.ClockFunc) (frontend.RolloutStatusSource, error) {
+	switch cfg.RolloutStatus.Source {
+	case "kubernetes":
+		source, err := rollout.NewInClusterSource(cfg.RolloutStatus.Namespace, cfg.RolloutStatus.Rollout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kubernetes rollout status source: %w", err)
+		}
+
+		return rollout.NewCachedSource(source, rollout.KubernetesCacheTTL, now), nil
+	case "file":
+		return rollout.NewFileSource(cfg.RolloutStatus.File), nil
+	case "http":
+		return rollout.NewHTTPSource(cfg.RolloutStatus.URL), nil
+	default:
+		return nil, nil //nolint:nilnil // No rollout status source is configured.
+	}
+}
+
+func backendTargets(targets []config.Target) []frontend.Target {
+	converted := make([]frontend.Target, len(targets))
+	for i, target := range targets {
+		converted[i] = frontend.Target{
+			Name: target.Name,
+			URL:  target.URL,
+		}
+	}
+
+	return converted
+}
+
+func tileColors(cfg *config.Config) frontend.TileColors {
+	return frontend.TileColors{
+		Palette: cfg.TilePalette(),
+		Mode:    cfg.Colors.Mode,
+		Gradient: frontend.Gradient{
+			Oldest: cfg.Colors.Gradient.Oldest,
+			Newest: cfg.Colors.Gradient.Newest,
+		},
+	}
+}
+
+func headerProfiles(profiles []config.HeaderProfile) []frontend.HeaderProfile {
+	converted := make([]frontend.HeaderProfile, len(profiles))
+	for i, profile := range profiles {
+		converted[i] = frontend.HeaderProfile{
+			Name:    profile.Name,
+			Headers: profile.Headers,
+		}
+	}
+
+	return converted
+}
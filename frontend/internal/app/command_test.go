@@ -0,0 +1,93 @@
+package app_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"phasor/frontend/internal/app"
+	"phasor/shared/configkit"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+const validConfig = `
+backend_url: "http://backend:8080/instance/info"
+environment: "test"
+log_config: {level: "info", format: "json"}
+tile_colors: ["#667eea"]
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	testastic.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestRunCommand(t *testing.T) {
+	t.Setenv("VERSION", "1.0.0")
+
+	t.Run("validate reports a valid config", func(t *testing.T) {
+		// GIVEN: a valid config file
+		path := writeConfig(t, validConfig)
+
+		var stdout bytes.Buffer
+
+		// WHEN: running validate with the path as -config
+		err := app.RunCommand(configkit.CommandValidate, []string{"-config", path}, "/config/config.yaml", &stdout)
+
+		// THEN: the config is reported as valid
+		testastic.NoError(t, err)
+		testastic.Equal(t, path+": valid\n", stdout.String())
+	})
+
+	t.Run("validate returns the validation errors", func(t *testing.T) {
+		// GIVEN: a config file with an invalid log level, passed as the default path
+		path := writeConfig(t, `log_config: {level: "verbose"}`)
+
+		// WHEN: running validate
+		err := app.RunCommand(configkit.CommandValidate, nil, path, &bytes.Buffer{})
+
+		// THEN: the invalid field is reported
+		testastic.ErrorContains(t, err, "log_config.level: invalid value")
+	})
+
+	t.Run("print-effective prints the config with defaults", func(t *testing.T) {
+		// GIVEN: a config file without shutdown settings
+		path := writeConfig(t, validConfig)
+
+		var stdout bytes.Buffer
+
+		// WHEN: running print-effective
+		err := app.RunCommand(configkit.CommandPrintEffective, []string{"-config", path}, "", &stdout)
+
+		// THEN: the version and the default shutdown settings are printed
+		testastic.NoError(t, err)
+		testastic.Contains(t, stdout.String(), "# version: 1.0.0 (VERSION)\n")
+		testastic.Contains(t, stdout.String(), "shutdown:\n  drain_period: 5s\n  timeout: 15s\n")
+	})
+
+	t.Run("schema prints the JSON Schema", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		// WHEN: running schema
+		err := app.RunCommand(configkit.CommandSchema, nil, "", &stdout)
+
+		// THEN: a JSON Schema is printed
+		testastic.NoError(t, err)
+		testastic.True(t, json.Valid(stdout.Bytes()))
+		testastic.Contains(t, stdout.String(), `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+	})
+
+	t.Run("unknown commands are rejected", func(t *testing.T) {
+		// WHEN: running an unknown command
+		err := app.RunCommand("lint", nil, "", &bytes.Buffer{})
+
+		// THEN: the command is rejected
+		testastic.ErrorIs(t, err, configkit.ErrUnknownCommand)
+	})
+}
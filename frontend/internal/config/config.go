@@ -1,47 +1,201 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// DefaultDrainPeriod is how long the server keeps serving after readiness starts failing.
+	DefaultDrainPeriod = 5 * time.Second
+	// DefaultShutdownTimeout is how long the server waits for in-flight requests during shutdown.
+	DefaultShutdownTimeout = 15 * time.Second
+	// DefaultBackendHealthInterval is the time between background backend health probes.
+	DefaultBackendHealthInterval = 5 * time.Second
+	// DefaultBackendHealthFailureThreshold is the number of consecutive failed probes before
+	// the backend is considered unhealthy.
+	DefaultBackendHealthFailureThreshold = 3
+	// DefaultBackendHealthPolicy fails readiness while the backend is unhealthy.
+	DefaultBackendHealthPolicy = "down"
+	// DefaultBackendURL is the instance info URL of the backend Service installed by the Helm
+	// chart under the release name phasor, used when neither backend_url nor targets are configured.
+	DefaultBackendURL = "http://phasor-backend/instance/info"
+	// DefaultEnvironment is the environment name used when none is configured.
+	DefaultEnvironment = "default"
+	// DefaultLogLevel is the log level used when none is configured.
+	DefaultLogLevel = "info"
+	// DefaultLogFormat is the log format used when none is configured.
+	DefaultLogFormat = "json"
+	// DisabledSecurityHeader omits a security header, e.g. when the ingress already sets it.
+	DisabledSecurityHeader = "-"
+)
+
 var (
-	// ErrTileColorsRequired is returned when tile_colors is not configured in the config file.
-	ErrTileColorsRequired = errors.New("tile_colors must be configured in the config file")
-	// ErrBackendURLRequired is returned when backend_url is not configured.
-	ErrBackendURLRequired = errors.New("backend_url must be configured in the config file")
 	// ErrConfigPathNotAbsolute is returned when the config file path is not absolute.
 	ErrConfigPathNotAbsolute = errors.New("config file path must be absolute")
-	// ErrEnvironmentRequired is returned when environment is not configured in the config file.
-	ErrEnvironmentRequired = errors.New("environment must be configured in the config file")
+	// ErrVersionRequired is returned when the VERSION environment variable is not set.
+	ErrVersionRequired = errors.New("VERSION environment variable is required")
+	// ErrPreviewURLsRequired is returned when only one of the preview URLs is configured.
+	ErrPreviewURLsRequired = errors.New("active_url and preview_url must be configured together")
 )
 
+// DefaultTargetName is the name of the target derived from backend_url when no targets are configured.
+const DefaultTargetName = "backend"
+
+// Target is a named instance API URL sampled by the dashboard, e.g. the stable,
+// canary or preview Service of the backend.
+type Target struct {
+	Name      string `yaml:"name"`       // Name shown in the UI
+	URL       string `yaml:"url"`        // Instance info URL of the target
+	HealthURL string `yaml:"health_url"` // Readiness URL of the target (default: resolved relative to url)
+}
+
+// HeaderProfile is a named set of request headers attached to backend requests,
+// e.g. to select a header-based canary route.
+type HeaderProfile struct {
+	Name    string            `yaml:"name"`    // Name shown in the UI
+	Headers map[string]string `yaml:"headers"` // Headers sent with every backend request
+}
+
+// SecurityHeaders holds the values of the security headers set on every response.
+// Empty values use the defaults, DisabledSecurityHeader omits the header.
+type SecurityHeaders struct {
+	ContentSecurityPolicy   string `yaml:"content_security_policy"`   // {nonce} is replaced by a per-request nonce
+	StrictTransportSecurity string `yaml:"strict_transport_security"` // Strict-Transport-Security (HSTS)
+	FrameOptions            string `yaml:"frame_options"`             // X-Frame-Options
+	ReferrerPolicy          string `yaml:"referrer_policy"`           // Referrer-Policy
+	PermissionsPolicy       string `yaml:"permissions_policy"`        // Permissions-Policy
+}
+
+// DefaultSecurityHeaders returns the default security headers. The Content-Security-Policy
+// only allows resources from the frontend itself and inline scripts carrying the nonce.
+func DefaultSecurityHeaders() SecurityHeaders {
+	return SecurityHeaders{
+		ContentSecurityPolicy: "default-src 'self'; script-src 'self' 'nonce-{nonce}'; " +
+			"style-src 'self' 'unsafe-inline'; img-src 'self' data:; connect-src 'self'; " +
+			"object-src 'none'; base-uri 'self'; form-action 'self'; frame-ancestors 'none'",
+		StrictTransportSecurity: "max-age=31536000; includeSubDomains",
+		FrameOptions:            "DENY",
+		ReferrerPolicy:          "strict-origin-when-cross-origin",
+		PermissionsPolicy:       "camera=(), geolocation=(), microphone=(), payment=(), usb=()",
+	}
+}
+
 // Config holds the frontend application configuration.
 type Config struct {
-	BackendURL  string   `yaml:"backend_url"` // URL of the backend service
-	Environment string   `yaml:"environment"` // Environment name (e.g., local, dev, staging, prod)
-	TileColors  []string `yaml:"tile_colors"` // Colors for instance tiles
-	LogConfig   struct {
+	Version          string          `yaml:"-"`                  // Set via VERSION environment variable only
+	BackendURL       string          `yaml:"backend_url"`        // URL of the backend service
+	BackendHealthURL string          `yaml:"backend_health_url"` // Readiness URL (default: relative to backend_url)
+	Targets          []Target        `yaml:"targets"`            // Backend targets compared side by side
+	Environment      string          `yaml:"environment"`        // Environment name (e.g., local, dev, staging, prod)
+	TileColors       []string        `yaml:"tile_colors"`        // Custom tile colors, overriding colors.palette
+	HeaderProfiles   []HeaderProfile `yaml:"header_profiles"`    // Named header profiles selectable on the index page
+	TemplatesDir     string          `yaml:"templates_dir"`      // Reloaded templates instead of embedded (development)
+	Colors           struct {
+		Palette  string `yaml:"palette"` // Named palette: default, colorblind or high-contrast
+		Mode     string `yaml:"mode"`    // hash (stable per key), distinct (no repeats) or semver (gradient)
+		Gradient struct {
+			Oldest string `yaml:"oldest"` // #rrggbb color of the oldest version in semver mode
+			Newest string `yaml:"newest"` // #rrggbb color of the newest version in semver mode
+		} `yaml:"gradient"`
+	} `yaml:"colors"`
+	LogConfig struct {
 		Level     string `yaml:"level"`      // Log level (debug, info, warn, error)
 		Format    string `yaml:"format"`     // Log format (json, text)
 		AddSource bool   `yaml:"add_source"` // Include source file and line number
 	} `yaml:"log_config"`
+	Shutdown struct {
+		DrainPeriod *time.Duration `yaml:"drain_period"` // Time to keep serving after readiness starts failing, 0s: none
+		Timeout     *time.Duration `yaml:"timeout"`      // Maximum time to wait for in-flight requests
+	} `yaml:"shutdown"`
+	BackendHealth struct {
+		Interval         time.Duration `yaml:"interval"`          // Time between background backend health probes
+		FailureThreshold int           `yaml:"failure_threshold"` // Consecutive failures before the backend is unhealthy
+		Policy           string        `yaml:"policy"`            // Effect of an unhealthy backend: down or degraded
+	} `yaml:"backend_health"`
+	Preview struct {
+		ActiveURL  string `yaml:"active_url"`  // Base URL of the active frontend Service (blue-green)
+		PreviewURL string `yaml:"preview_url"` // Base URL of the preview frontend Service (blue-green)
+	} `yaml:"preview"`
+	RolloutStatus struct {
+		Source    string `yaml:"source"`    // Rollout status source: kubernetes, file or http (default: disabled)
+		Namespace string `yaml:"namespace"` // Namespace of the Rollout (default: the pod's namespace)
+		Rollout   string `yaml:"rollout"`   // Name of the backend Rollout (kubernetes source)
+		File      string `yaml:"file"`      // Path of a JSON rollout status file (file source)
+		URL       string `yaml:"url"`       // URL serving the JSON rollout status (http source)
+	} `yaml:"rollout_status"`
+	SecurityHeaders SecurityHeaders `yaml:"security_headers"` // Security headers set on every response
 }
 
-// Load reads configuration from the specified YAML file.
+// Load reads configuration from the specified YAML file and environment variables.
+// The VERSION environment variable is required and must be set; it cannot be configured via the config file.
+// Fields missing from the file get their defaults, and an empty path runs on the defaults alone.
+// Unknown keys are rejected, and all invalid fields are reported at once with their YAML paths.
 func Load(path string) (*Config, error) {
-	cleanPath := filepath.Clean(path)
-	if !filepath.IsAbs(cleanPath) {
-		return nil, fmt.Errorf("%w: %s", ErrConfigPathNotAbsolute, path)
+	return load(path, true)
+}
+
+// LoadFile reads and validates only the configuration file, like Load without the values that
+// are only read from the environment, e.g. to check a rendered ConfigMap before it is deployed.
+func LoadFile(path string) (*Config, error) {
+	return load(path, false)
+}
+
+func load(path string, fromEnv bool) (*Config, error) {
+	var cfg Config
+
+	source := "(no config file)"
+
+	// Unknown keys and mistyped values are reported together with the validation errors.
+	var decodeErr error
+
+	if path != "" {
+		cleanPath := filepath.Clean(path)
+		if !filepath.IsAbs(cleanPath) {
+			return nil, fmt.Errorf("%w: %s", ErrConfigPathNotAbsolute, path)
+		}
+
+		source = cleanPath
+
+		var typeErr *yaml.TypeError
+
+		decodeErr = decodeFile(cleanPath, &cfg)
+		if decodeErr != nil && !errors.As(decodeErr, &typeErr) {
+			return nil, decodeErr
+		}
+	}
+
+	var envErr error
+
+	if fromEnv {
+		cfg.Version = os.Getenv("VERSION")
+		envErr = cfg.validateEnv()
 	}
 
-	configFile, err := os.Open(cleanPath)
+	cfg.ApplyDefaults()
+
+	err := errors.Join(decodeErr, envErr, cfg.validate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s:\n%w", source, err)
+	}
+
+	return &cfg, nil
+}
+
+// decodeFile decodes the YAML file at path into cfg, rejecting unknown keys. An empty file
+// leaves cfg unchanged.
+func decodeFile(path string, cfg *Config) (err error) {
+	configFile, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open config file: %w", err)
+		return fmt.Errorf("failed to open config file: %w", err)
 	}
 
 	defer func() {
@@ -51,26 +205,222 @@ func Load(path string) (*Config, error) {
 		}
 	}()
 
-	var cfg Config
-
 	decoder := yaml.NewDecoder(configFile)
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return nil
+}
+
+// BackendTargets returns the configured targets, or a single target named
+// DefaultTargetName for backend_url if none are configured.
+func (c *Config) BackendTargets() []Target {
+	if len(c.Targets) > 0 {
+		return c.Targets
+	}
+
+	return []Target{{Name: DefaultTargetName, URL: c.BackendURL, HealthURL: c.BackendHealthURL}}
+}
 
-	err = decoder.Decode(&cfg)
+// Checksum returns the SHA-256 checksum of the effective configuration, excluding the version,
+// so that two deployments can be compared for configuration drift.
+func (c *Config) Checksum() (string, error) {
+	data, err := yaml.Marshal(c)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode config: %w", err)
+		return "", fmt.Errorf("failed to encode config: %w", err)
 	}
 
-	if cfg.BackendURL == "" {
-		return nil, ErrBackendURLRequired
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validateEnv checks the values that are only read from the environment.
+func (c *Config) validateEnv() error {
+	if c.Version == "" {
+		return ErrVersionRequired
 	}
 
-	if cfg.Environment == "" {
-		return nil, ErrEnvironmentRequired
+	return nil
+}
+
+// validate checks every field of the config file and returns all problems joined, each with
+// its YAML path.
+func (c *Config) validate() error {
+	var v validator
+
+	v.optionalURL("backend_url", c.BackendURL)
+	v.optionalURL("backend_health_url", c.BackendHealthURL)
+	validateTargets(&v, c.Targets)
+
+	for i, color := range c.TileColors {
+		v.color(fmt.Sprintf("tile_colors[%d]", i), color)
 	}
 
-	if len(cfg.TileColors) == 0 {
-		return nil, ErrTileColorsRequired
+	v.OneOf("colors.palette", c.Colors.Palette, PaletteNames()...)
+	v.OneOf("colors.mode", c.Colors.Mode, colorModes...)
+	v.hexColor("colors.gradient.oldest", c.Colors.Gradient.Oldest)
+	v.hexColor("colors.gradient.newest", c.Colors.Gradient.Newest)
+	validateHeaderProfiles(&v, c.HeaderProfiles)
+
+	v.OneOf("log_config.level", c.LogConfig.Level, logLevels...)
+	v.OneOf("log_config.format", c.LogConfig.Format, logFormats...)
+	v.NonNegative("shutdown.drain_period", int64(*c.Shutdown.DrainPeriod))
+	v.NonNegative("shutdown.timeout", int64(*c.Shutdown.Timeout))
+
+	v.NonNegative("backend_health.interval", int64(c.BackendHealth.Interval))
+	v.NonNegative("backend_health.failure_threshold", int64(c.BackendHealth.FailureThreshold))
+
+	v.OneOf("backend_health.policy", c.BackendHealth.Policy, backendHealthPolicies...)
+
+	if (c.Preview.ActiveURL == "") != (c.Preview.PreviewURL == "") {
+		v.Add("preview", ErrPreviewURLsRequired)
 	}
 
-	return &cfg, nil
+	v.optionalURL("preview.active_url", c.Preview.ActiveURL)
+	v.optionalURL("preview.preview_url", c.Preview.PreviewURL)
+	c.validateRolloutStatus(&v)
+
+	return v.Err()
+}
+
+// ApplyDefaults fills in the defaults of every field that is not configured, like Load does
+// after decoding the file.
+func (c *Config) ApplyDefaults() {
+	if c.BackendURL == "" && len(c.Targets) == 0 {
+		c.BackendURL = DefaultBackendURL
+	}
+
+	if c.Environment == "" {
+		c.Environment = DefaultEnvironment
+	}
+
+	if c.Colors.Palette == "" {
+		c.Colors.Palette = DefaultPalette
+	}
+
+	if c.Colors.Mode == "" {
+		c.Colors.Mode = DefaultColorMode
+	}
+
+	if c.Colors.Gradient.Oldest == "" {
+		c.Colors.Gradient.Oldest = DefaultGradientOldest
+	}
+
+	if c.Colors.Gradient.Newest == "" {
+		c.Colors.Gradient.Newest = DefaultGradientNewest
+	}
+
+	if c.LogConfig.Level == "" {
+		c.LogConfig.Level = DefaultLogLevel
+	}
+
+	if c.LogConfig.Format == "" {
+		c.LogConfig.Format = DefaultLogFormat
+	}
+
+	// The shutdown durations are pointers, so that an explicit 0s is kept.
+	if c.Shutdown.DrainPeriod == nil {
+		drainPeriod := DefaultDrainPeriod
+		c.Shutdown.DrainPeriod = &drainPeriod
+	}
+
+	if c.Shutdown.Timeout == nil {
+		timeout := DefaultShutdownTimeout
+		c.Shutdown.Timeout = &timeout
+	}
+
+	if c.BackendHealth.Interval == 0 {
+		c.BackendHealth.Interval = DefaultBackendHealthInterval
+	}
+
+	if c.BackendHealth.FailureThreshold == 0 {
+		c.BackendHealth.FailureThreshold = DefaultBackendHealthFailureThreshold
+	}
+
+	if c.BackendHealth.Policy == "" {
+		c.BackendHealth.Policy = DefaultBackendHealthPolicy
+	}
+
+	applySecurityHeaderDefaults(&c.SecurityHeaders)
+}
+
+func (c *Config) validateRolloutStatus(v *validator) {
+	status := c.RolloutStatus
+
+	switch status.Source {
+	case "":
+	case "kubernetes":
+		v.required("rollout_status.rollout", status.Rollout)
+	case "file":
+		v.required("rollout_status.file", status.File)
+	case "http":
+		v.required("rollout_status.url", status.URL)
+		v.optionalURL("rollout_status.url", status.URL)
+	default:
+		v.OneOf("rollout_status.source", status.Source, rolloutStatusSources...)
+	}
+}
+
+// applySecurityHeaderDefaults fills in the default of every empty header and clears the
+// headers set to DisabledSecurityHeader.
+func applySecurityHeaderDefaults(headers *SecurityHeaders) {
+	defaults := DefaultSecurityHeaders()
+
+	for _, header := range []struct {
+		value        *string
+		defaultValue string
+	}{
+		{&headers.ContentSecurityPolicy, defaults.ContentSecurityPolicy},
+		{&headers.StrictTransportSecurity, defaults.StrictTransportSecurity},
+		{&headers.FrameOptions, defaults.FrameOptions},
+		{&headers.ReferrerPolicy, defaults.ReferrerPolicy},
+		{&headers.PermissionsPolicy, defaults.PermissionsPolicy},
+	} {
+		switch *header.value {
+		case "":
+			*header.value = header.defaultValue
+		case DisabledSecurityHeader:
+			*header.value = ""
+		}
+	}
+}
+
+func validateTargets(v *validator, targets []Target) {
+	seen := make(map[string]bool, len(targets))
+
+	for i, target := range targets {
+		path := fmt.Sprintf("targets[%d]", i)
+
+		v.required(path+".name", target.Name)
+		v.required(path+".url", target.URL)
+		v.optionalURL(path+".url", target.URL)
+		v.optionalURL(path+".health_url", target.HealthURL)
+
+		if target.Name != "" && seen[target.Name] {
+			v.Addf(path+".name", ErrDuplicate, "%q", target.Name)
+		}
+
+		seen[target.Name] = true
+	}
+}
+
+func validateHeaderProfiles(v *validator, profiles []HeaderProfile) {
+	seen := make(map[string]bool, len(profiles))
+
+	for i, profile := range profiles {
+		path := fmt.Sprintf("header_profiles[%d]", i)
+
+		v.required(path+".name", profile.Name)
+
+		if profile.Name != "" && seen[profile.Name] {
+			v.Addf(path+".name", ErrDuplicate, "%q", profile.Name)
+		}
+
+		seen[profile.Name] = true
+	}
 }
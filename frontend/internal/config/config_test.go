@@ -0,0 +1,278 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"phasor/frontend/internal/config"
+	"phasor/shared/configkit"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+const validConfig = `
+backend_url: "http://backend:8080/instance/info"
+environment: "test"
+log_config:
+  level: "info"
+  format: "json"
+tile_colors:
+  - "#667eea"
+  - "rgb(240, 147, 251)"
+  - "teal"
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	testastic.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("VERSION", "1.0.0")
+
+	t.Run("valid config is loaded with defaults", func(t *testing.T) {
+		// GIVEN: a config with only the backend, environment, logging and tile colors configured
+		path := writeConfig(t, validConfig)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: optional fields get their defaults
+		testastic.NoError(t, err)
+		testastic.Equal(t, "1.0.0", cfg.Version)
+		testastic.Equal(t, config.DefaultDrainPeriod, *cfg.Shutdown.DrainPeriod)
+		testastic.Equal(t, config.DefaultBackendHealthInterval, cfg.BackendHealth.Interval)
+		testastic.Equal(t, config.DefaultBackendHealthPolicy, cfg.BackendHealth.Policy)
+		testastic.Equal(t, config.DefaultSecurityHeaders(), cfg.SecurityHeaders)
+	})
+
+	t.Run("without a config file only the defaults are used", func(t *testing.T) {
+		// WHEN: loading the config without a path
+		cfg, err := config.Load("")
+
+		// THEN: every field gets its default
+		testastic.NoError(t, err)
+		testastic.Equal(t, config.DefaultBackendURL, cfg.BackendURL)
+		testastic.Equal(t, config.DefaultEnvironment, cfg.Environment)
+		testastic.Equal(t, config.DefaultLogLevel, cfg.LogConfig.Level)
+		testastic.Equal(t, config.DefaultLogFormat, cfg.LogConfig.Format)
+		testastic.Equal(t, config.DefaultPalette, cfg.Colors.Palette)
+		testastic.Equal(t, config.DefaultColorMode, cfg.Colors.Mode)
+		testastic.Equal(t, config.DefaultGradientOldest, cfg.Colors.Gradient.Oldest)
+		testastic.Equal(t, config.DefaultGradientNewest, cfg.Colors.Gradient.Newest)
+		testastic.Equal(t, config.DefaultBackendHealthPolicy, cfg.BackendHealth.Policy)
+	})
+
+	t.Run("tile colors override the palette for both themes", func(t *testing.T) {
+		// GIVEN: a config with tile colors and a named palette
+		path := writeConfig(t, validConfig+`colors: {palette: "colorblind", mode: "distinct"}`)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: the tile colors are used in both themes
+		testastic.NoError(t, err)
+		testastic.Equal(t, "#667eea", cfg.TilePalette().Light[0])
+		testastic.Equal(t, "#667eea", cfg.TilePalette().Dark[0])
+		testastic.Equal(t, "distinct", cfg.Colors.Mode)
+	})
+
+	t.Run("named palettes have as many dark as light colors", func(t *testing.T) {
+		for _, name := range config.PaletteNames() {
+			// GIVEN: a config selecting the palette
+			path := writeConfig(t, `colors: {palette: "`+name+`"}`)
+
+			// WHEN: loading the config
+			cfg, err := config.Load(path)
+
+			// THEN: both variants can be indexed alike
+			testastic.NoError(t, err)
+			testastic.Equal(t, len(cfg.TilePalette().Light), len(cfg.TilePalette().Dark))
+		}
+	})
+
+	t.Run("backend URL is not defaulted when targets are configured", func(t *testing.T) {
+		// GIVEN: a config with targets but without backend_url
+		path := writeConfig(t, `targets: [{name: "stable", url: "http://stable/instance/info"}]`)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: only the targets are sampled
+		testastic.NoError(t, err)
+		testastic.Equal(t, "", cfg.BackendURL)
+		testastic.Equal(t, 1, len(cfg.BackendTargets()))
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		// wantErrors maps the YAML paths expected in the error to their sentinel errors.
+		wantErrors map[string]error
+	}{
+		{
+			name: "invalid values are reported with their paths",
+			content: `
+backend_url: "backend:8080/instance/info"
+environment: "test"
+log_config: {level: "verbose", format: "json"}
+tile_colors: ["#667eea", "not-a-color", "#12345"]
+shutdown: {drain_period: "-1s"}
+`,
+			wantErrors: map[string]error{
+				"backend_url":           config.ErrInvalidURL,
+				"log_config.level":      configkit.ErrInvalidValue,
+				"tile_colors[1]":        config.ErrInvalidColor,
+				"tile_colors[2]":        config.ErrInvalidColor,
+				"shutdown.drain_period": configkit.ErrNegative,
+			},
+		},
+		{
+			name: "targets are validated per entry",
+			content: validConfig + `
+targets:
+  - name: "stable"
+    url: "http://stable/instance/info"
+  - name: "stable"
+    url: "http://canary/instance/info"
+  - url: "http://preview/instance/info"
+    health_url: "preview/health/ready"
+  - name: "missing-url"
+`,
+			wantErrors: map[string]error{
+				"targets[1].name":       config.ErrDuplicate,
+				"targets[2].name":       config.ErrRequired,
+				"targets[2].health_url": config.ErrInvalidURL,
+				"targets[3].url":        config.ErrRequired,
+			},
+		},
+		{
+			name: "header profiles are validated per entry",
+			content: validConfig + `
+header_profiles:
+  - name: "canary"
+  - name: "canary"
+  - headers: {X-Canary: "always"}
+`,
+			wantErrors: map[string]error{
+				"header_profiles[1].name": config.ErrDuplicate,
+				"header_profiles[2].name": config.ErrRequired,
+			},
+		},
+		{
+			name: "backend health, preview and rollout status sections are validated",
+			content: validConfig + `
+backend_health: {interval: "-5s", failure_threshold: -1, policy: "ignore"}
+preview: {active_url: "http://phasor-frontend"}
+rollout_status: {source: "file"}
+`,
+			wantErrors: map[string]error{
+				"backend_health.interval":          configkit.ErrNegative,
+				"backend_health.failure_threshold": configkit.ErrNegative,
+				"backend_health.policy":            configkit.ErrInvalidValue,
+				"preview":                          config.ErrPreviewURLsRequired,
+				"rollout_status.file":              config.ErrRequired,
+			},
+		},
+		{
+			name:    "unknown palette and color mode are rejected",
+			content: validConfig + `colors: {palette: "neon", mode: "random"}`,
+			wantErrors: map[string]error{
+				"colors.palette": configkit.ErrInvalidValue,
+				"colors.mode":    configkit.ErrInvalidValue,
+			},
+		},
+		{
+			name:    "gradient colors must be #rrggbb colors",
+			content: validConfig + `colors: {mode: "semver", gradient: {oldest: "red", newest: "#1dd"}}`,
+			wantErrors: map[string]error{
+				"colors.gradient.oldest": config.ErrInvalidHexColor,
+				"colors.gradient.newest": config.ErrInvalidHexColor,
+			},
+		},
+		{
+			name:    "unknown rollout status source is rejected",
+			content: validConfig + `rollout_status: {source: "argocd"}`,
+			wantErrors: map[string]error{
+				"rollout_status.source": configkit.ErrInvalidValue,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// GIVEN: an invalid config
+			path := writeConfig(t, tt.content)
+
+			// WHEN: loading the config
+			cfg, err := config.Load(path)
+
+			// THEN: every problem is reported with its YAML path
+			testastic.Nil(t, cfg)
+
+			for fieldPath, wantErr := range tt.wantErrors {
+				testastic.ErrorIs(t, err, wantErr)
+				testastic.ErrorContains(t, err, "\n"+fieldPath+": ")
+			}
+		})
+	}
+
+	t.Run("unknown keys are rejected together with validation errors", func(t *testing.T) {
+		// GIVEN: a config with a misspelled key
+		path := writeConfig(t, `
+backend_url: "http://backend:8080/instance/info"
+environment: "test"
+log_config: {level: "verbose"}
+tile_colours: ["#667eea"]
+`)
+
+		// WHEN: loading the config
+		_, err := config.Load(path)
+
+		// THEN: both the unknown key and the invalid field are reported
+		testastic.ErrorContains(t, err, "field tile_colours not found")
+		testastic.ErrorContains(t, err, "log_config.level: invalid value")
+	})
+
+	t.Run("version is required", func(t *testing.T) {
+		t.Setenv("VERSION", "")
+
+		// GIVEN: a valid config without the VERSION environment variable
+		path := writeConfig(t, validConfig)
+
+		// WHEN: loading the config
+		_, err := config.Load(path)
+
+		// THEN: the missing version is reported
+		testastic.ErrorIs(t, err, config.ErrVersionRequired)
+	})
+
+	t.Run("durations are decoded", func(t *testing.T) {
+		// GIVEN: a config with a custom drain period
+		path := writeConfig(t, validConfig+`shutdown: {drain_period: "1s"}`)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: the configured value is kept
+		testastic.NoError(t, err)
+		testastic.Equal(t, time.Second, *cfg.Shutdown.DrainPeriod)
+	})
+
+	t.Run("explicit zero drain period is kept", func(t *testing.T) {
+		// GIVEN: a config that turns the drain off
+		path := writeConfig(t, validConfig+`shutdown: {drain_period: "0s"}`)
+
+		// WHEN: loading the config
+		cfg, err := config.Load(path)
+
+		// THEN: the zero is not replaced with the default
+		testastic.NoError(t, err)
+		testastic.Equal(t, time.Duration(0), *cfg.Shutdown.DrainPeriod)
+	})
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+const (
+	// DefaultPalette is the palette used when neither colors.palette nor tile_colors are configured.
+	DefaultPalette = "default"
+	// DefaultColorMode colors tiles by a hash of their hostname and version.
+	DefaultColorMode = "hash"
+	// DefaultGradientOldest is the color of the oldest version in semver mode.
+	DefaultGradientOldest = "#ff6348"
+	// DefaultGradientNewest is the color of the newest version in semver mode.
+	DefaultGradientNewest = "#1dd1a1"
+)
+
+// Palette is a set of tile colors with a variant for the dark theme. Both variants have the
+// same number of colors, so that a tile keeps its color when the theme is switched.
+type Palette struct {
+	Light []string
+	Dark  []string
+}
+
+// palettes are the named palettes selectable with colors.palette.
+var palettes = map[string]Palette{
+	"default": {
+		Light: []string{"#667eea", "#f093fb", "#4facfe", "#43e97b", "#fa709a", "#feca57", "#ff6348", "#1dd1a1"},
+		Dark:  []string{"#8c9eff", "#f5b0fc", "#7cc4ff", "#6ff09a", "#fc98b8", "#ffd97a", "#ff8a75", "#4be3bf"},
+	},
+	// colorblind is the Okabe-Ito palette, which stays distinguishable with color vision deficiencies.
+	"colorblind": {
+		Light: []string{"#0072b2", "#e69f00", "#009e73", "#cc79a7", "#56b4e9", "#d55e00", "#f0e442", "#999999"},
+		Dark:  []string{"#56b4e9", "#e69f00", "#1fbf8f", "#dd9cc2", "#8ccdf2", "#f07a30", "#f0e442", "#bbbbbb"},
+	},
+	"high-contrast": {
+		Light: []string{"#0000cc", "#cc0000", "#007700", "#9900cc", "#b35900", "#006688", "#cc0077", "#333333"},
+		Dark:  []string{"#66aaff", "#ff6666", "#33dd33", "#dd88ff", "#ffaa33", "#33ddee", "#ff66bb", "#eeeeee"},
+	},
+}
+
+// PaletteNames returns the sorted names of the palettes selectable with colors.palette.
+func PaletteNames() []string {
+	return slices.Sorted(maps.Keys(palettes))
+}
+
+// TilePalette returns the configured tile_colors for both themes, or the configured palette.
+func (c *Config) TilePalette() Palette {
+	if len(c.TileColors) > 0 {
+		return Palette{Light: c.TileColors, Dark: c.TileColors}
+	}
+
+	return palettes[c.Colors.Palette]
+}
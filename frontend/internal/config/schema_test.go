@@ -0,0 +1,45 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"phasor/frontend/internal/config"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+const chartValuesSchema = "../../../chart/values.schema.json"
+
+func TestJSONSchemaMatchesChart(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN: the schema generated from Config
+	data, err := config.JSONSchema()
+	testastic.NoError(t, err)
+
+	var generated map[string]any
+	testastic.NoError(t, json.Unmarshal(data, &generated))
+
+	// The chart embeds the schema without its dialect, which is set once for the whole file.
+	delete(generated, "$schema")
+
+	// WHEN: reading the config schema embedded in the chart's values schema
+	data, err = os.ReadFile(chartValuesSchema)
+	testastic.NoError(t, err)
+
+	var values struct {
+		Defs map[string]any `json:"$defs"` //nolint:tagliatelle // JSON Schema keyword.
+	}
+
+	testastic.NoError(t, json.Unmarshal(data, &values))
+
+	// THEN: both are the same, otherwise the chart needs `make schemas`
+	want, err := json.Marshal(generated)
+	testastic.NoError(t, err)
+
+	got, err := json.Marshal(values.Defs["frontendConfig"])
+	testastic.NoError(t, err)
+
+	testastic.Equal(t, string(want), string(got))
+}
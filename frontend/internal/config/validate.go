@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"phasor/shared/configkit"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrRequired is returned when a required field is not configured.
+	ErrRequired = errors.New("must be configured")
+	// ErrInvalidURL is returned when a URL is not an absolute http or https URL.
+	ErrInvalidURL = errors.New("must be an absolute http or https URL")
+	// ErrInvalidColor is returned when a tile color is not a CSS color.
+	ErrInvalidColor = errors.New("must be a CSS hex, rgb(), hsl() or named color")
+	// ErrInvalidHexColor is returned when a gradient color is not a #rrggbb color.
+	ErrInvalidHexColor = errors.New("must be a #rrggbb hex color")
+	// ErrDuplicate is returned when a name is used more than once in a list.
+	ErrDuplicate = errors.New("must be unique")
+)
+
+// Allowed values of enumerated fields, shared by validate and JSONSchema.
+var (
+	logLevels             = []string{"debug", "info", "warn", "error"}
+	logFormats            = []string{"json", "text"}
+	backendHealthPolicies = []string{"down", "degraded"}
+	rolloutStatusSources  = []string{"kubernetes", "file", "http"}
+	colorModes            = []string{"hash", "distinct", "semver"}
+)
+
+var (
+	hexColorPattern        = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	rrggbbColorPattern     = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	functionalColorPattern = regexp.MustCompile(`^(rgb|rgba|hsl|hsla)\([0-9.,%/\sa-z]+\)$`)
+)
+
+// namedColors are the CSS named colors accepted as tile colors.
+var namedColors = []string{
+	"aliceblue", "antiquewhite", "aqua", "aquamarine", "azure", "beige", "bisque", "black",
+	"blanchedalmond", "blue", "blueviolet", "brown", "burlywood", "cadetblue", "chartreuse",
+	"chocolate", "coral", "cornflowerblue", "cornsilk", "crimson", "cyan", "darkblue", "darkcyan",
+	"darkgoldenrod", "darkgray", "darkgreen", "darkgrey", "darkkhaki", "darkmagenta",
+	"darkolivegreen", "darkorange", "darkorchid", "darkred", "darksalmon", "darkseagreen",
+	"darkslateblue", "darkslategray", "darkslategrey", "darkturquoise", "darkviolet", "deeppink",
+	"deepskyblue", "dimgray", "dimgrey", "dodgerblue", "firebrick", "floralwhite", "forestgreen",
+	"fuchsia", "gainsboro", "ghostwhite", "gold", "goldenrod", "gray", "green", "greenyellow",
+	"grey", "honeydew", "hotpink", "indianred", "indigo", "ivory", "khaki", "lavender",
+	"lavenderblush", "lawngreen", "lemonchiffon", "lightblue", "lightcoral", "lightcyan",
+	"lightgoldenrodyellow", "lightgray", "lightgreen", "lightgrey", "lightpink", "lightsalmon",
+	"lightseagreen", "lightskyblue", "lightslategray", "lightslategrey", "lightsteelblue",
+	"lightyellow", "lime", "limegreen", "linen", "magenta", "maroon", "mediumaquamarine",
+	"mediumblue", "mediumorchid", "mediumpurple", "mediumseagreen", "mediumslateblue",
+	"mediumspringgreen", "mediumturquoise", "mediumvioletred", "midnightblue", "mintcream",
+	"mistyrose", "moccasin", "navajowhite", "navy", "oldlace", "olive", "olivedrab", "orange",
+	"orangered", "orchid", "palegoldenrod", "palegreen", "paleturquoise", "palevioletred",
+	"papayawhip", "peachpuff", "peru", "pink", "plum", "powderblue", "purple", "rebeccapurple",
+	"red", "rosybrown", "royalblue", "saddlebrown", "salmon", "sandybrown", "seagreen", "seashell",
+	"sienna", "silver", "skyblue", "slateblue", "slategray", "slategrey", "snow", "springgreen",
+	"steelblue", "tan", "teal", "thistle", "tomato", "turquoise", "violet", "wheat", "white",
+	"whitesmoke", "yellow", "yellowgreen",
+}
+
+// validator adds the frontend's URL and color checks to the shared validation.
+type validator struct {
+	configkit.Validator
+}
+
+func (v *validator) required(path, value string) {
+	if value == "" {
+		v.Add(path, ErrRequired)
+	}
+}
+
+// optionalURL validates value as an absolute http or https URL unless it is empty.
+func (v *validator) optionalURL(path, value string) {
+	if value == "" {
+		return
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		v.Addf(path, ErrInvalidURL, "%q", value)
+	}
+}
+
+func (v *validator) color(path, value string) {
+	if hexColorPattern.MatchString(value) ||
+		functionalColorPattern.MatchString(value) ||
+		slices.Contains(namedColors, strings.ToLower(value)) {
+		return
+	}
+
+	v.Addf(path, ErrInvalidColor, "%q", value)
+}
+
+// hexColor validates value as a #rrggbb color, which can be interpolated.
+func (v *validator) hexColor(path, value string) {
+	if !rrggbbColorPattern.MatchString(value) {
+		v.Addf(path, ErrInvalidHexColor, "%q", value)
+	}
+}
@@ -0,0 +1,135 @@
+package frontend
+
+import (
+	"hash/fnv"
+	"html/template"
+	"phasor/frontend/internal/config"
+	"slices"
+	"sync"
+)
+
+const (
+	// ColorModeHash colors each hostname and version by its hash, so different versions may share a color.
+	ColorModeHash = "hash"
+	// ColorModeDistinct gives different versions and hostnames of one response different colors,
+	// as long as the palette has enough of them.
+	ColorModeDistinct = "distinct"
+	// ColorModeSemver colors versions on a gradient from the oldest to the newest semantic version
+	// of one response. Hostnames and other versions are colored by hash.
+	ColorModeSemver = "semver"
+
+	themeDark = "dark"
+	// maxColorAssignments bounds the remembered assignments, which grow with every new pod hostname.
+	maxColorAssignments = 1024
+)
+
+// TileColors configures how instance tiles are colored. Gradient is only used in semver mode.
+type TileColors struct {
+	Palette  config.Palette
+	Mode     string
+	Gradient Gradient
+}
+
+// themeColors returns the palette's colors for the theme requested by the index page.
+func themeColors(palette config.Palette, theme string) []string {
+	if theme == themeDark {
+		return palette.Dark
+	}
+
+	return palette.Light
+}
+
+// tileColor marks color as safe for the tiles' style attributes, whose CSS escaping would
+// otherwise replace functional colors like rgb(240, 147, 251) with ZgotmplZ. Palette colors
+// are validated when the configuration is loaded and gradient colors are #rrggbb.
+func tileColor(color string) template.CSS {
+	return template.CSS(color) //nolint:gosec // The color was validated as a CSS color value.
+}
+
+// colorAssigner assigns palette indices to keys, e.g. hostnames or versions.
+type colorAssigner struct {
+	size     int
+	distinct bool
+
+	mu sync.Mutex
+	// assigned remembers the index of every key in distinct mode, so colors are stable across refreshes
+	assigned map[string]int
+}
+
+// newColorAssigner creates an assigner for a palette of size colors in the given color mode.
+func newColorAssigner(size int, mode string) *colorAssigner {
+	return &colorAssigner{
+		size:     size,
+		distinct: mode == ColorModeDistinct,
+		assigned: make(map[string]int),
+	}
+}
+
+// assign returns the palette index of every key. In distinct mode a key keeps its previous
+// index unless another key of the same call already uses it, and a new key gets the first
+// index after its hash that no other key of the call uses.
+func (a *colorAssigner) assign(keys []string) map[string]int {
+	indices := make(map[string]int, len(keys))
+
+	if !a.distinct {
+		for _, key := range keys {
+			indices[key] = hashIndex(key, a.size)
+		}
+
+		return indices
+	}
+
+	// Sorting makes the assignment of new keys independent of the order tiles were sampled in.
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	used := make([]bool, a.size)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, key := range keys {
+		index, ok := a.assigned[key]
+		if ok && !used[index] {
+			indices[key] = index
+			used[index] = true
+		}
+	}
+
+	if len(a.assigned)+len(keys) > maxColorAssignments {
+		clear(a.assigned)
+	}
+
+	for _, key := range keys {
+		if _, ok := indices[key]; ok {
+			a.assigned[key] = indices[key]
+
+			continue
+		}
+
+		index := firstUnused(used, hashIndex(key, a.size))
+		indices[key] = index
+		used[index] = true
+		a.assigned[key] = index
+	}
+
+	return indices
+}
+
+// hashIndex returns the palette index of key derived from its FNV hash.
+func hashIndex(key string, size int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return int(h.Sum32()) % size
+}
+
+// firstUnused returns the first unused index starting at start, or start if all are used.
+func firstUnused(used []bool, start int) int {
+	for offset := range used {
+		index := (start + offset) % len(used)
+		if !used[index] {
+			return index
+		}
+	}
+
+	return start
+}
@@ -6,12 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"hash/fnv"
 	"html/template"
 	"net/http"
-	"path/filepath"
+	"phasor/frontend/internal/config"
+	"phasor/frontend/internal/security"
 	"slices"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -48,74 +49,85 @@ type InstanceInfoResponse struct {
 
 // FrontendHandler handles frontend HTTP requests for the web UI.
 type FrontendHandler struct {
-	templates      *template.Template
+	templates      *Templates
+	version        string
 	instanceClient *http.Client
-	instanceURL    string
-	tileColors     []string
+	targets        []Target
+	palette        config.Palette
+	hostnameColors *colorAssigner
+	versionColors  *colorAssigner
+	// versionGradient colors versions by semantic version in semver mode, otherwise it is nil
+	versionGradient *Gradient
+	headerProfiles  []HeaderProfile
+	rolloutSource   RolloutStatusSource
+	now             ClockFunc
+}
+
+// Target is a named instance API URL sampled by the dashboard.
+type Target struct {
+	Name string
+	URL  string
 }
 
 // InstanceTileData represents data for a single instance tile in the UI.
 type InstanceTileData struct {
 	Index         int
 	Info          InstanceInfoResponse
-	Color         string
-	HostnameColor string
+	Color         template.CSS
+	HostnameColor template.CSS
 }
 
-// TilesData holds the collection of instance tiles to render.
+// TilesData holds the groups of instance tiles to render, one per target and selected header profile,
+// and the intended rollout status if a rollout status source is configured.
 type TilesData struct {
-	Instances []InstanceTileData
-}
-
-// colorPalette holds a list of colors for deterministic assignment.
-type colorPalette struct {
-	colors []string
-}
-
-// newColorPalette creates a new color palette with the given colors.
-func newColorPalette(colors []string) *colorPalette {
-	return &colorPalette{colors: colors}
-}
-
-// getColor returns a deterministic color for the given key using hash-based assignment.
-// The same key always returns the same color across requests.
-func (cp *colorPalette) getColor(key string) string {
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(key))
-	idx := int(h.Sum32()) % len(cp.colors)
-
-	return cp.colors[idx]
+	Groups       []TileGroup
+	Rollout      *RolloutStatus
+	RolloutError string
 }
 
 // IndexData contains data for rendering the index page.
 type IndexData struct {
-	Count int
+	Version  string
+	Count    int
+	Profiles []string
+	Nonce    string
 }
 
-// errorInstanceInfo returns an InstanceInfoResponse for error cases.
-func errorInstanceInfo() InstanceInfoResponse {
+// errorInstanceInfo returns an InstanceInfoResponse for error cases, timestamped with the handler's clock.
+func (h *FrontendHandler) errorInstanceInfo() InstanceInfoResponse {
 	return InstanceInfoResponse{
 		Version:   "error",
 		Hostname:  "failed to fetch",
 		Uptime:    "N/A",
 		GoVersion: "N/A",
-		Timestamp: time.Now(),
+		Timestamp: h.now(),
 	}
 }
 
-// NewFrontendHandler creates a new frontend handler with the specified templates path,
-// instance API URL, and tile colors.
+// NewFrontendHandler creates a new frontend handler with the specified templates,
+// frontend version, instance API targets, tile colors, and selectable header profiles.
+// Hostnames and versions are colored independently from the same palette.
+// The rollout status source is optional; when nil, no rollout status is shown.
+// Failed fetches are timestamped with the clock now.
 func NewFrontendHandler(
-	templatesPath, instanceURL string,
-	tileColors []string,
-) (*FrontendHandler, error) {
-	tmpl, err := template.ParseGlob(filepath.Join(templatesPath, "*.gohtml"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	templates *Templates,
+	version string,
+	targets []Target,
+	tileColors TileColors,
+	headerProfiles []HeaderProfile,
+	rolloutSource RolloutStatusSource,
+	now ClockFunc,
+) *FrontendHandler {
+	paletteSize := len(tileColors.Palette.Light)
+
+	var versionGradient *Gradient
+	if tileColors.Mode == ColorModeSemver {
+		versionGradient = &tileColors.Gradient
 	}
 
 	return &FrontendHandler{
-		templates: tmpl,
+		templates: templates,
+		version:   version,
 		instanceClient: &http.Client{
 			Timeout: httpClientTimeout,
 			Transport: &http.Transport{
@@ -126,15 +138,24 @@ func NewFrontendHandler(
 				MaxIdleConnsPerHost: transportMaxIdlePerHost,
 			},
 		},
-		instanceURL: instanceURL,
-		tileColors:  tileColors,
-	}, nil
+		targets:         targets,
+		palette:         tileColors.Palette,
+		hostnameColors:  newColorAssigner(paletteSize, tileColors.Mode),
+		versionColors:   newColorAssigner(paletteSize, tileColors.Mode),
+		versionGradient: versionGradient,
+		headerProfiles:  headerProfiles,
+		rolloutSource:   rolloutSource,
+		now:             now,
+	}
 }
 
 // IndexHandler serves the main index page with the default tile count.
-func (h *FrontendHandler) IndexHandler(writer http.ResponseWriter, _ *http.Request) {
+func (h *FrontendHandler) IndexHandler(writer http.ResponseWriter, req *http.Request) {
 	data := IndexData{
-		Count: defaultTileCount,
+		Version:  h.version,
+		Count:    defaultTileCount,
+		Profiles: h.profileNames(),
+		Nonce:    security.Nonce(req.Context()),
 	}
 
 	err := h.templates.ExecuteTemplate(writer, "index.gohtml", data)
@@ -149,9 +170,13 @@ func (h *FrontendHandler) IndexHandler(writer http.ResponseWriter, _ *http.Reque
 	}
 }
 
-// TilesHandler renders instance tiles based on the count query parameter.
+// TilesHandler renders instance tiles based on the count query parameter. Tiles are sampled
+// concurrently from every target once per selected header profile, with the extra headers
+// from the headers query parameter attached to every request. Tiles are colored from the
+// palette variant of the theme query parameter.
 func (h *FrontendHandler) TilesHandler(writer http.ResponseWriter, req *http.Request) {
-	countStr := req.URL.Query().Get("count")
+	query := req.URL.Query()
+	countStr := query.Get("count")
 	count := defaultTileCount
 
 	if countStr != "" {
@@ -161,64 +186,162 @@ func (h *FrontendHandler) TilesHandler(writer http.ResponseWriter, req *http.Req
 		}
 	}
 
-	palette := newColorPalette(h.tileColors)
+	extraHeaders := parseHeaders(query.Get("headers"))
+	profiles := h.selectProfiles(query["profile"])
+
+	groups := make([]TileGroup, 0, len(h.targets)*len(profiles))
+	for _, target := range h.targets {
+		for _, profile := range profiles {
+			groups = append(groups, TileGroup{
+				Target:  target.Name,
+				Profile: profile.Name,
+				Headers: mergeHeaders(profile.Headers, extraHeaders),
+			})
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range groups {
+		group := &groups[i]
+		url := h.targets[i/len(profiles)].URL
+
+		wg.Go(func() {
+			group.Instances = h.sampleInstances(req.Context(), url, group.Headers, count)
+		})
+	}
+
+	data := TilesData{
+		Groups: groups,
+	}
+
+	if h.rolloutSource != nil {
+		wg.Go(func() {
+			status, err := h.rolloutSource.RolloutStatus(req.Context())
+			if err != nil {
+				data.RolloutError = err.Error()
+
+				return
+			}
+
+			data.Rollout = &status
+		})
+	}
+
+	wg.Wait()
+
+	h.colorTiles(groups, themeColors(h.palette, query.Get("theme")))
+
+	if data.Rollout != nil {
+		for i := range groups {
+			groups[i].ObservedCanaryPercent = observedCanaryPercent(groups[i].Instances, data.Rollout.CanaryHash)
+		}
+	}
 
+	err := h.templates.ExecuteTemplate(writer, "tiles.gohtml", data)
+	if err != nil {
+		http.Error(
+			writer,
+			fmt.Sprintf("failed to render tiles: %v", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+}
+
+// sampleInstances fetches the instance info from url count times with the given headers and
+// returns the tiles sorted by version and hostname.
+func (h *FrontendHandler) sampleInstances(
+	ctx context.Context,
+	url string,
+	headers map[string]string,
+	count int,
+) []InstanceTileData {
 	instances := make([]InstanceTileData, count)
 	for i := range count {
-		info, err := h.fetchInstanceInfo(req.Context())
+		info, err := h.fetchInstanceInfo(ctx, url, headers)
 		if err != nil {
-			info = errorInstanceInfo()
+			info = h.errorInstanceInfo()
 		}
 
-		tileColor := palette.getColor(info.Hostname + "|" + info.Version)
 		instances[i] = InstanceTileData{
-			Index:         i + 1,
-			Info:          info,
-			Color:         tileColor,
-			HostnameColor: tileColor,
+			Index: i + 1,
+			Info:  info,
 		}
 	}
 
-	// Sort by Hostname (descending), then Version (descending)
+	// Sort by semantic Version (descending), then Hostname (descending), so the newest version comes first
 	slices.SortFunc(instances, func(a, b InstanceTileData) int {
-		if result := cmp.Compare(b.Info.Hostname, a.Info.Hostname); result != 0 {
+		if result := compareVersions(b.Info.Version, a.Info.Version); result != 0 {
 			return result
 		}
 
-		return cmp.Compare(b.Info.Version, a.Info.Version)
+		return cmp.Compare(b.Info.Hostname, a.Info.Hostname)
 	})
 
 	for i := range instances {
 		instances[i].Index = i + 1
 	}
 
-	data := TilesData{
-		Instances: instances,
+	return instances
+}
+
+// colorTiles colors the hostname and version of every tile in all groups together, so that
+// distinct mode avoids repeated colors and semver mode spans the gradient across the whole
+// response, and then computes the version distribution of every group.
+func (h *FrontendHandler) colorTiles(groups []TileGroup, colors []string) {
+	var hostnames, versions []string
+
+	for _, group := range groups {
+		for _, instance := range group.Instances {
+			hostnames = append(hostnames, instance.Info.Hostname)
+			versions = append(versions, instance.Info.Version)
+		}
 	}
 
-	err := h.templates.ExecuteTemplate(writer, "tiles.gohtml", data)
-	if err != nil {
-		http.Error(
-			writer,
-			fmt.Sprintf("failed to render tiles: %v", err),
-			http.StatusInternalServerError,
-		)
+	hostnameIndices := h.hostnameColors.assign(hostnames)
+	versionIndices := h.versionColors.assign(versions)
 
-		return
+	var gradientColors map[string]string
+	if h.versionGradient != nil {
+		gradientColors = h.versionGradient.colors(versions)
+	}
+
+	for i := range groups {
+		instances := groups[i].Instances
+		for j := range instances {
+			version := instances[j].Info.Version
+
+			instances[j].HostnameColor = tileColor(colors[hostnameIndices[instances[j].Info.Hostname]])
+			instances[j].Color = tileColor(colors[versionIndices[version]])
+
+			if gradientColor, ok := gradientColors[version]; ok {
+				instances[j].Color = tileColor(gradientColor)
+			}
+		}
+
+		groups[i].Distribution = versionDistribution(instances)
 	}
 }
 
 func (h *FrontendHandler) fetchInstanceInfo(
 	ctx context.Context,
+	url string,
+	headers map[string]string,
 ) (InstanceInfoResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, httpRequestTimeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.instanceURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return InstanceInfoResponse{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
 	resp, err := h.instanceClient.Do(req)
 	if err != nil {
 		return InstanceInfoResponse{}, fmt.Errorf(
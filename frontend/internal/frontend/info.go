@@ -0,0 +1,81 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// HostnameFunc is a function that returns the hostname.
+type HostnameFunc func() string
+
+// ClockFunc is a function that returns the current time.
+type ClockFunc func() time.Time
+
+// FrontendInfoResponse describes a running frontend instance. It mirrors the backend's
+// instance info and adds the checksum of the effective configuration.
+type FrontendInfoResponse struct {
+	// ConfigChecksum SHA-256 checksum of the effective configuration
+	ConfigChecksum string `json:"config_checksum"`
+
+	// GoVersion Go runtime version
+	GoVersion string `json:"go_version"`
+
+	// Hostname Instance hostname
+	Hostname string `json:"hostname"`
+
+	// Timestamp Current server timestamp
+	Timestamp time.Time `json:"timestamp"`
+
+	// Uptime Human-readable process uptime
+	Uptime string `json:"uptime"`
+
+	// Version Application version
+	Version string `json:"version"`
+}
+
+// InfoHandler handles frontend instance information requests.
+type InfoHandler struct {
+	version        string
+	configChecksum string
+	getHostname    HostnameFunc
+	now            ClockFunc
+	startTime      time.Time
+}
+
+// NewInfoHandler creates a new info handler with the specified version, configuration
+// checksum, hostname function and clock. Uptime is measured from the clock's time at creation.
+func NewInfoHandler(version, configChecksum string, getHostname HostnameFunc, now ClockFunc) *InfoHandler {
+	return &InfoHandler{
+		version:        version,
+		configChecksum: configChecksum,
+		getHostname:    getHostname,
+		now:            now,
+		startTime:      now(),
+	}
+}
+
+// GetFrontendInfo returns information about the running frontend instance so that
+// blue-green colours can be told apart.
+func (h *InfoHandler) GetFrontendInfo(writer http.ResponseWriter, _ *http.Request) {
+	now := h.now()
+
+	response := FrontendInfoResponse{
+		ConfigChecksum: h.configChecksum,
+		Version:        h.version,
+		Hostname:       h.getHostname(),
+		Uptime:         now.Sub(h.startTime).String(),
+		GoVersion:      runtime.Version(),
+		Timestamp:      now,
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	encodeErr := json.NewEncoder(writer).Encode(response)
+	if encodeErr != nil {
+		http.Error(writer, "failed to encode response", http.StatusInternalServerError)
+
+		return
+	}
+}
@@ -0,0 +1,154 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"phasor/frontend/internal/security"
+	"strings"
+	"sync"
+)
+
+const previewHealthUnknown = "unknown"
+
+// PreviewHandler renders a page comparing the active and preview frontends of a
+// blue-green rollout, to help decide whether to promote the preview.
+type PreviewHandler struct {
+	templates  *Templates
+	client     *http.Client
+	activeURL  string
+	previewURL string
+}
+
+// PreviewSide describes one frontend of the blue-green comparison.
+type PreviewSide struct {
+	Name   string
+	URL    string
+	Info   FrontendInfoResponse
+	Error  string
+	Health string
+}
+
+// PreviewData contains data for rendering the preview comparison page.
+type PreviewData struct {
+	Active          PreviewSide
+	Preview         PreviewSide
+	SameVersion     bool
+	SameConfig      bool
+	BothHealthy     bool
+	ReadyToPromote  bool
+	ComparisonError bool
+	Nonce           string
+}
+
+// NewPreviewHandler creates a new preview handler comparing the frontends served at the
+// active and preview base URLs.
+func NewPreviewHandler(templates *Templates, activeURL, previewURL string) *PreviewHandler {
+	return &PreviewHandler{
+		templates: templates,
+		client: &http.Client{
+			Timeout: httpClientTimeout,
+		},
+		activeURL:  strings.TrimSuffix(activeURL, "/"),
+		previewURL: strings.TrimSuffix(previewURL, "/"),
+	}
+}
+
+// PreviewPageHandler fetches the info and readiness of both frontends concurrently and
+// renders them side by side.
+func (h *PreviewHandler) PreviewPageHandler(writer http.ResponseWriter, req *http.Request) {
+	var (
+		active  PreviewSide
+		preview PreviewSide
+		wg      sync.WaitGroup
+	)
+
+	wg.Go(func() { active = h.fetchSide(req.Context(), "active", h.activeURL) })
+	wg.Go(func() { preview = h.fetchSide(req.Context(), "preview", h.previewURL) })
+	wg.Wait()
+
+	data := PreviewData{
+		Active:          active,
+		Preview:         preview,
+		SameVersion:     active.Info.Version == preview.Info.Version,
+		SameConfig:      active.Info.ConfigChecksum == preview.Info.ConfigChecksum,
+		BothHealthy:     active.Health == "ok" && preview.Health == "ok",
+		ComparisonError: active.Error != "" || preview.Error != "",
+		Nonce:           security.Nonce(req.Context()),
+	}
+	// A config-only rollout keeps the version but changes the checksum, which is worth promoting too.
+	data.ReadyToPromote = data.BothHealthy && !data.ComparisonError && !(data.SameVersion && data.SameConfig)
+
+	err := h.templates.ExecuteTemplate(writer, "preview.gohtml", data)
+	if err != nil {
+		http.Error(
+			writer,
+			fmt.Sprintf("failed to render preview: %v", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+}
+
+// fetchSide fetches the info and readiness of the frontend at baseURL.
+func (h *PreviewHandler) fetchSide(ctx context.Context, name, baseURL string) PreviewSide {
+	side := PreviewSide{
+		Name:   name,
+		URL:    baseURL,
+		Health: previewHealthUnknown,
+	}
+
+	err := h.getJSON(ctx, baseURL+"/frontend/info", &side.Info)
+	if err != nil {
+		side.Error = err.Error()
+	}
+
+	var ready struct {
+		Status string `json:"status"`
+	}
+
+	// Readiness responds with 503 and a status body when failing, so the body is decoded either way.
+	err = h.getJSON(ctx, baseURL+"/health/ready", &ready)
+	if err == nil || errors.Is(err, ErrUnexpectedStatusCode) {
+		if ready.Status != "" {
+			side.Health = ready.Status
+		}
+	}
+
+	return side
+}
+
+// getJSON decodes the JSON response body of a GET request into target. A non-200 status
+// is reported as ErrUnexpectedStatusCode after the body has been decoded.
+func (h *PreviewHandler) getJSON(ctx context.Context, url string, target any) error {
+	ctx, cancel := context.WithTimeout(ctx, httpRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	err = json.NewDecoder(resp.Body).Decode(target)
+	if err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package frontend
+
+import (
+	"html/template"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	defaultProfileName = "default"
+	percent            = 100
+)
+
+// HeaderProfile is a named set of request headers attached to instance API requests.
+type HeaderProfile struct {
+	Name    string
+	Headers map[string]string
+}
+
+// TileGroup holds the tiles sampled from one target with one header profile.
+type TileGroup struct {
+	Target       string
+	Profile      string
+	Headers      map[string]string
+	Instances    []InstanceTileData
+	Distribution []VersionShare
+	// ObservedCanaryPercent is the share of tiles served by canary pods when the rollout status is known.
+	ObservedCanaryPercent int
+}
+
+// VersionShare describes how many of a group's tiles were served by one version.
+type VersionShare struct {
+	Version string
+	Count   int
+	Percent int
+	Color   template.CSS
+}
+
+// selectProfiles returns the configured profiles matching the requested names in
+// configuration order, or a single default profile without headers if none match.
+func (h *FrontendHandler) selectProfiles(names []string) []HeaderProfile {
+	selected := make([]HeaderProfile, 0, len(names))
+
+	for _, profile := range h.headerProfiles {
+		if slices.Contains(names, profile.Name) {
+			selected = append(selected, profile)
+		}
+	}
+
+	if len(selected) == 0 {
+		selected = append(selected, HeaderProfile{Name: defaultProfileName})
+	}
+
+	return selected
+}
+
+// profileNames returns the names of all configured header profiles.
+func (h *FrontendHandler) profileNames() []string {
+	names := make([]string, len(h.headerProfiles))
+	for i, profile := range h.headerProfiles {
+		names[i] = profile.Name
+	}
+
+	return names
+}
+
+// parseHeaders parses free-form "Name: value" lines into headers. Lines without a
+// colon or with an empty name are ignored.
+func parseHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+
+	for line := range strings.Lines(raw) {
+		name, value, found := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+
+		if !found || name == "" {
+			continue
+		}
+
+		headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+
+	return headers
+}
+
+// mergeHeaders returns the profile headers overridden by the extra headers.
+func mergeHeaders(profile, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(profile)+len(extra))
+
+	for name, value := range profile {
+		merged[http.CanonicalHeaderKey(name)] = value
+	}
+
+	maps.Copy(merged, extra)
+
+	return merged
+}
+
+// versionDistribution counts the tiles per version, sorted by semantic version (descending). Each
+// version has the color of its tiles.
+func versionDistribution(instances []InstanceTileData) []VersionShare {
+	counts := make(map[string]int)
+	colors := make(map[string]template.CSS)
+
+	for _, instance := range instances {
+		counts[instance.Info.Version]++
+		colors[instance.Info.Version] = instance.Color
+	}
+
+	distribution := make([]VersionShare, 0, len(counts))
+	for version, count := range counts {
+		distribution = append(distribution, VersionShare{
+			Version: version,
+			Count:   count,
+			Percent: count * percent / len(instances),
+			Color:   colors[version],
+		})
+	}
+
+	slices.SortFunc(distribution, func(a, b VersionShare) int {
+		return compareVersions(b.Version, a.Version)
+	})
+
+	return distribution
+}
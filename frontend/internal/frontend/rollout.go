@@ -0,0 +1,54 @@
+package frontend
+
+import (
+	"context"
+	"strings"
+)
+
+// RolloutStatus is the state Argo Rollouts intends for the backend rollout.
+type RolloutStatus struct {
+	// Name of the Rollout resource
+	Name string `json:"name"`
+
+	// Phase of the rollout, e.g. Progressing, Paused or Healthy
+	Phase string `json:"phase"`
+
+	// CurrentStep is the number of canary steps completed
+	CurrentStep int `json:"current_step"`
+
+	// TotalSteps is the number of canary steps defined in the strategy
+	TotalSteps int `json:"total_steps"`
+
+	// CanaryWeight is the percentage of traffic the current step sends to the canary
+	CanaryWeight int `json:"canary_weight"`
+
+	// StableHash is the pod template hash of the stable ReplicaSet
+	StableHash string `json:"stable_hash"`
+
+	// CanaryHash is the pod template hash of the canary ReplicaSet, empty when fully promoted
+	CanaryHash string `json:"canary_hash"`
+}
+
+// RolloutStatusSource provides the current rollout status, e.g. from the Kubernetes API.
+type RolloutStatusSource interface {
+	RolloutStatus(ctx context.Context) (RolloutStatus, error)
+}
+
+// observedCanaryPercent returns the percentage of tiles served by canary pods. Pods created
+// by Argo Rollouts are named <rollout>-<pod-template-hash>-<suffix>, so canary tiles are
+// recognized by the canary hash in their hostname.
+func observedCanaryPercent(instances []InstanceTileData, canaryHash string) int {
+	if canaryHash == "" || len(instances) == 0 {
+		return 0
+	}
+
+	canary := 0
+
+	for _, instance := range instances {
+		if strings.Contains(instance.Info.Hostname, "-"+canaryHash+"-") {
+			canary++
+		}
+	}
+
+	return canary * percent / len(instances)
+}
@@ -0,0 +1,107 @@
+package frontend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// StaticPrefix is the URL path under which static assets are served.
+	StaticPrefix = "/static/"
+
+	staticHashLength   = 12
+	staticCacheControl = "public, max-age=31536000, immutable"
+)
+
+// ErrUnknownStaticAsset is returned when a template references a static asset that is not embedded.
+var ErrUnknownStaticAsset = errors.New("unknown static asset")
+
+//go:embed static
+var embeddedStatic embed.FS
+
+// StaticAssets serves the embedded JavaScript and CSS files. Each file is served under a
+// name containing a hash of its content, so responses can be cached forever and a new
+// release is picked up by browsers immediately.
+type StaticAssets struct {
+	// paths maps asset names to their hashed URL paths
+	paths map[string]string
+	// files maps hashed names to asset contents
+	files map[string][]byte
+}
+
+// NewStaticAssets hashes the embedded static files.
+func NewStaticAssets() (*StaticAssets, error) {
+	assets := &StaticAssets{
+		paths: make(map[string]string),
+		files: make(map[string][]byte),
+	}
+
+	err := fs.WalkDir(embeddedStatic, "static", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := embeddedStatic.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read static asset %s: %w", name, err)
+		}
+
+		assetName := strings.TrimPrefix(name, "static/")
+		hashedName := hashedAssetName(assetName, content)
+
+		assets.paths[assetName] = StaticPrefix + hashedName
+		assets.files[hashedName] = content
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load static assets: %w", err)
+	}
+
+	return assets, nil
+}
+
+// Path returns the hashed URL path of the named asset, for use in templates.
+func (s *StaticAssets) Path(name string) (string, error) {
+	assetPath, ok := s.paths[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownStaticAsset, name)
+	}
+
+	return assetPath, nil
+}
+
+// ServeHTTP serves the asset with the hashed name at the end of the request path.
+// Only hashed names are served, so a cached response can never become stale.
+func (s *StaticAssets) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	hashedName := strings.TrimPrefix(req.URL.Path, StaticPrefix)
+
+	content, ok := s.files[hashedName]
+	if !ok {
+		http.NotFound(writer, req)
+
+		return
+	}
+
+	writer.Header().Set("Cache-Control", staticCacheControl)
+	http.ServeContent(writer, req, hashedName, time.Time{}, bytes.NewReader(content))
+}
+
+// hashedAssetName inserts a hash of content before the extension, e.g. dashboard.css
+// becomes dashboard.1a2b3c4d5e6f.css.
+func hashedAssetName(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:staticHashLength]
+	ext := path.Ext(name)
+
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
@@ -0,0 +1,102 @@
+package frontend
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+)
+
+const templatesPattern = "*.gohtml"
+
+//go:embed templates/*.gohtml
+var embeddedTemplates embed.FS
+
+// Templates renders the page templates. Embedded templates are parsed once; templates read
+// from an override directory are parsed on every render so that edits show up without a
+// restart.
+type Templates struct {
+	fsys   fs.FS
+	reload bool
+	funcs  template.FuncMap
+	parsed *template.Template
+}
+
+// NewEmbeddedTemplates returns the templates compiled into the binary, so the page always
+// matches the binary's version. Templates link static assets with {{static "name"}}.
+func NewEmbeddedTemplates(static *StaticAssets) (*Templates, error) {
+	fsys, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded templates: %w", err)
+	}
+
+	return newTemplates(fsys, false, static)
+}
+
+// NewDirTemplates returns templates read from dir instead of the embedded ones. They are
+// re-read on every render, which is meant for template development.
+func NewDirTemplates(dir string, static *StaticAssets) (*Templates, error) {
+	return newTemplates(os.DirFS(dir), true, static)
+}
+
+// ExecuteTemplate renders the named template with data to writer. The template is rendered
+// into a buffer first, so a failed render writes nothing and the caller can still respond with
+// an error instead of a page cut off at the failure.
+func (t *Templates) ExecuteTemplate(writer io.Writer, name string, data any) error {
+	tmpl := t.parsed
+
+	if t.reload {
+		var err error
+
+		tmpl, err = parseTemplates(t.fsys, t.funcs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var rendered bytes.Buffer
+
+	err := tmpl.ExecuteTemplate(&rendered, name, data)
+	if err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+
+	_, err = rendered.WriteTo(writer)
+	if err != nil {
+		return fmt.Errorf("failed to write template %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// newTemplates parses the templates up front, so that broken templates fail at startup
+// even when they are reloaded later.
+func newTemplates(fsys fs.FS, reload bool, static *StaticAssets) (*Templates, error) {
+	funcs := template.FuncMap{
+		"static": static.Path,
+	}
+
+	parsed, err := parseTemplates(fsys, funcs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Templates{
+		fsys:   fsys,
+		reload: reload,
+		funcs:  funcs,
+		parsed: parsed,
+	}, nil
+}
+
+func parseTemplates(fsys fs.FS, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(funcs).ParseFS(fsys, templatesPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,113 @@
+package frontend
+
+import (
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+const hexColorLength = len("#rrggbb")
+
+// Gradient is the pair of #rrggbb colors that versions are colored between in semver mode.
+type Gradient struct {
+	Oldest string
+	Newest string
+}
+
+// colors returns a color per semantic version, interpolated from Oldest for the oldest to
+// Newest for the newest version. A single version gets Newest. Versions that are no semantic
+// version are left out.
+func (g Gradient) colors(versions []string) map[string]string {
+	var semvers []string
+
+	for _, version := range versions {
+		if canonicalSemver(version) != "" {
+			semvers = append(semvers, version)
+		}
+	}
+
+	slices.SortFunc(semvers, compareVersions)
+	semvers = slices.Compact(semvers)
+
+	colors := make(map[string]string, len(semvers))
+	for i, version := range semvers {
+		position := 1.0
+		if len(semvers) > 1 {
+			position = float64(i) / float64(len(semvers)-1)
+		}
+
+		colors[version] = interpolateColor(g.Oldest, g.Newest, position)
+	}
+
+	return colors
+}
+
+// compareVersions orders versions by semantic version, so that 0.10.0 follows 0.9.0. Versions
+// with or without a "v" prefix are accepted. Other versions, e.g. "error", order before all
+// semantic versions and among themselves by string.
+func compareVersions(a, b string) int {
+	semverA, semverB := canonicalSemver(a), canonicalSemver(b)
+
+	switch {
+	case semverA != "" && semverB != "":
+		if result := semver.Compare(semverA, semverB); result != 0 {
+			return result
+		}
+	case semverA != "":
+		return 1
+	case semverB != "":
+		return -1
+	}
+
+	return cmp.Compare(a, b)
+}
+
+// canonicalSemver returns version with a "v" prefix if it is a semantic version, or "".
+func canonicalSemver(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	if !semver.IsValid(version) {
+		return ""
+	}
+
+	return version
+}
+
+// interpolateColor returns the #rrggbb color at position between 0 (from) and 1 (to). If
+// either color is not a #rrggbb color, to is returned.
+func interpolateColor(from, to string, position float64) string {
+	fromRGB, fromOK := parseHexColor(from)
+	toRGB, toOK := parseHexColor(to)
+
+	if !fromOK || !toOK {
+		return to
+	}
+
+	var rgb [3]uint8
+	for i := range rgb {
+		rgb[i] = uint8(math.Round(float64(fromRGB[i]) + (float64(toRGB[i])-float64(fromRGB[i]))*position))
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// parseHexColor returns the red, green and blue channels of a #rrggbb color.
+func parseHexColor(color string) ([]byte, bool) {
+	if len(color) != hexColorLength || color[0] != '#' {
+		return nil, false
+	}
+
+	rgb, err := hex.DecodeString(color[1:])
+	if err != nil {
+		return nil, false
+	}
+
+	return rgb, true
+}
@@ -2,9 +2,12 @@ package health
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/monkescience/vital"
@@ -14,45 +17,179 @@ const (
 	healthCheckTimeout = 2 * time.Second
 )
 
-// BackendChecker checks the health of the backend service.
+// ErrBackendUnhealthy is returned when the backend health endpoint returns a non-200 status code.
+var ErrBackendUnhealthy = errors.New("backend returned unhealthy status")
+
+// BackendPolicy decides how an unhealthy backend affects the frontend's readiness.
+type BackendPolicy string
+
+const (
+	// BackendPolicyDown fails readiness while the backend is unhealthy.
+	BackendPolicyDown BackendPolicy = "down"
+	// BackendPolicyDegraded keeps readiness passing and reports the backend as degraded.
+	BackendPolicyDegraded BackendPolicy = "degraded"
+)
+
+// BackendCheckerConfig configures how often the backend is probed and when it is considered unhealthy.
+type BackendCheckerConfig struct {
+	// Interval is the time between background probes.
+	Interval time.Duration
+	// FailureThreshold is the number of consecutive failed probes before the backend is unhealthy.
+	FailureThreshold int
+	// Policy decides whether an unhealthy backend fails readiness or only degrades it.
+	Policy BackendPolicy
+}
+
+// BackendChecker checks the health of the backend service. Probes run in the background
+// and Check reports the cached result, so a flaky backend does not make the frontend's
+// readiness flap: the backend is only unhealthy after FailureThreshold consecutive failures
+// and is healthy again after the next successful probe. Until the first probe has finished,
+// Check reports an error regardless of the policy.
 type BackendChecker struct {
 	client    *http.Client
+	name      string
 	healthURL string
+	cfg       BackendCheckerConfig
+	logger    *slog.Logger
+	probed    chan struct{}
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastErr             error
 }
 
-// NewBackendChecker creates a new backend health checker from the backend URL.
-// It derives the health endpoint by using the base URL with /health/ready path.
-func NewBackendChecker(backendURL string) (*BackendChecker, error) {
-	parsed, err := url.Parse(backendURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse backend URL: %w", err)
-	}
+// NewBackendChecker creates a new backend health checker with the given name. Unless
+// healthURL is set, the health endpoint is resolved relative to the backend instance URL,
+// so a backend exposed under a path prefix such as /api/instance/info is probed at
+// /api/health/ready. Call Start to begin probing.
+func NewBackendChecker(
+	name, backendURL, healthURL string,
+	cfg BackendCheckerConfig,
+	logger *slog.Logger,
+) (*BackendChecker, error) {
+	if healthURL == "" {
+		derived, err := deriveHealthURL(backendURL)
+		if err != nil {
+			return nil, err
+		}
 
-	healthURL := fmt.Sprintf("%s://%s/health/ready", parsed.Scheme, parsed.Host)
+		healthURL = derived
+	}
 
 	return &BackendChecker{
 		client: &http.Client{
 			Timeout: healthCheckTimeout,
 		},
+		name:      name,
 		healthURL: healthURL,
+		cfg:       cfg,
+		logger:    logger,
+		probed:    make(chan struct{}),
 	}, nil
 }
 
 // Name returns the name of this health check.
 func (c *BackendChecker) Name() string {
-	return "backend"
+	return c.name
+}
+
+// Check reports the result of the most recent background probes.
+func (c *BackendChecker) Check(_ context.Context) (vital.Status, string) {
+	select {
+	case <-c.probed:
+	default:
+		return vital.StatusError, "waiting for the first probe"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lastErr == nil {
+		return vital.StatusOK, ""
+	}
+
+	if c.consecutiveFailures < c.cfg.FailureThreshold {
+		return vital.StatusOK, fmt.Sprintf(
+			"failing (%d/%d): %v",
+			c.consecutiveFailures,
+			c.cfg.FailureThreshold,
+			c.lastErr,
+		)
+	}
+
+	if c.cfg.Policy == BackendPolicyDegraded {
+		return vital.StatusOK, fmt.Sprintf("degraded: %v", c.lastErr)
+	}
+
+	return vital.StatusError, c.lastErr.Error()
 }
 
-// Check performs a health check against the backend service.
-func (c *BackendChecker) Check(ctx context.Context) (vital.Status, string) {
+// Start probes the backend in the background, first right away and then every Interval
+// until the context is canceled. It does not wait for the first probe, see Probed.
+func (c *BackendChecker) Start(ctx context.Context) {
+	go func() {
+		c.probe(ctx)
+		close(c.probed)
+
+		ticker := time.NewTicker(c.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.probe(ctx)
+			}
+		}
+	}()
+}
+
+// Probed returns a channel that is closed once the first probe has finished.
+func (c *BackendChecker) Probed() <-chan struct{} {
+	return c.probed
+}
+
+// probe checks the backend and records the result.
+func (c *BackendChecker) probe(ctx context.Context) {
+	err := c.checkBackend(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasHealthy := c.consecutiveFailures < c.cfg.FailureThreshold
+
+	c.lastErr = err
+	if err == nil {
+		c.consecutiveFailures = 0
+	} else {
+		c.consecutiveFailures++
+	}
+
+	isHealthy := c.consecutiveFailures < c.cfg.FailureThreshold
+
+	switch {
+	case wasHealthy && !isHealthy:
+		c.logger.WarnContext(ctx, "backend became unhealthy",
+			slog.String("health_url", c.healthURL),
+			slog.Int("consecutive_failures", c.consecutiveFailures),
+			slog.Any("error", err),
+		)
+	case !wasHealthy && isHealthy:
+		c.logger.InfoContext(ctx, "backend recovered", slog.String("health_url", c.healthURL))
+	}
+}
+
+// checkBackend performs a single request against the backend health endpoint.
+func (c *BackendChecker) checkBackend(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.healthURL, nil)
 	if err != nil {
-		return vital.StatusError, fmt.Sprintf("failed to create request: %v", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return vital.StatusError, fmt.Sprintf("failed to reach backend: %v", err)
+		return fmt.Errorf("failed to reach backend: %w", err)
 	}
 
 	defer func() {
@@ -60,8 +197,18 @@ func (c *BackendChecker) Check(ctx context.Context) (vital.Status, string) {
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return vital.StatusError, fmt.Sprintf("backend returned status %d", resp.StatusCode)
+		return fmt.Errorf("%w: %d", ErrBackendUnhealthy, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// deriveHealthURL resolves the readiness endpoint relative to the backend instance URL.
+func deriveHealthURL(backendURL string) (string, error) {
+	parsed, err := url.Parse(backendURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse backend URL: %w", err)
 	}
 
-	return vital.StatusOK, ""
+	return parsed.ResolveReference(&url.URL{Path: "../health/ready"}).String(), nil
 }
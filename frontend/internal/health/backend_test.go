@@ -0,0 +1,286 @@
+package health_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"phasor/frontend/internal/health"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+	"github.com/monkescience/vital"
+)
+
+const (
+	noBackgroundProbes = time.Hour
+	eventuallyTimeout  = 2 * time.Second
+)
+
+// fakeBackend serves /health/ready with a configurable status code and counts the probes.
+type fakeBackend struct {
+	*httptest.Server
+
+	status atomic.Int32
+	probes atomic.Int32
+}
+
+func newFakeBackend(t *testing.T, status int) *fakeBackend {
+	t.Helper()
+
+	return newFakeBackendAt(t, "/health/ready", status)
+}
+
+func newFakeBackendAt(t *testing.T, healthPath string, status int) *fakeBackend {
+	t.Helper()
+
+	backend := &fakeBackend{}
+	backend.status.Store(int32(status))
+	backend.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != healthPath {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		backend.probes.Add(1)
+		writer.WriteHeader(int(backend.status.Load()))
+	}))
+	t.Cleanup(backend.Close)
+
+	return backend
+}
+
+func newStartedChecker(t *testing.T, backendURL string, cfg health.BackendCheckerConfig) *health.BackendChecker {
+	t.Helper()
+
+	return newStartedCheckerWithHealthURL(t, backendURL+"/instance/info", "", cfg)
+}
+
+func newStartedCheckerWithHealthURL(
+	t *testing.T,
+	backendURL, healthURL string,
+	cfg health.BackendCheckerConfig,
+) *health.BackendChecker {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	checker, err := health.NewBackendChecker("backend", backendURL, healthURL, cfg, logger)
+	testastic.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	checker.Start(ctx)
+	<-checker.Probed()
+
+	return checker
+}
+
+func TestBackendChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		backendStatus   int
+		cfg             health.BackendCheckerConfig
+		expectedStatus  vital.Status
+		expectedMessage string
+	}{
+		{
+			name:            "healthy backend is ok",
+			backendStatus:   http.StatusOK,
+			cfg:             health.BackendCheckerConfig{FailureThreshold: 1, Policy: health.BackendPolicyDown},
+			expectedStatus:  vital.StatusOK,
+			expectedMessage: "",
+		},
+		{
+			name:            "failure below threshold is still ok",
+			backendStatus:   http.StatusServiceUnavailable,
+			cfg:             health.BackendCheckerConfig{FailureThreshold: 3, Policy: health.BackendPolicyDown},
+			expectedStatus:  vital.StatusOK,
+			expectedMessage: "failing (1/3): backend returned unhealthy status: 503",
+		},
+		{
+			name:            "failure at threshold with down policy is an error",
+			backendStatus:   http.StatusServiceUnavailable,
+			cfg:             health.BackendCheckerConfig{FailureThreshold: 1, Policy: health.BackendPolicyDown},
+			expectedStatus:  vital.StatusError,
+			expectedMessage: "backend returned unhealthy status: 503",
+		},
+		{
+			name:            "failure at threshold with degraded policy is ok",
+			backendStatus:   http.StatusServiceUnavailable,
+			cfg:             health.BackendCheckerConfig{FailureThreshold: 1, Policy: health.BackendPolicyDegraded},
+			expectedStatus:  vital.StatusOK,
+			expectedMessage: "degraded: backend returned unhealthy status: 503",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// GIVEN: a checker that has probed the fake backend once
+			backend := newFakeBackend(t, tt.backendStatus)
+			tt.cfg.Interval = noBackgroundProbes
+			checker := newStartedChecker(t, backend.URL, tt.cfg)
+
+			// WHEN: checking the backend
+			status, message := checker.Check(t.Context())
+
+			// THEN: the cached probe result is reported according to threshold and policy
+			testastic.Equal(t, tt.expectedStatus, status)
+			testastic.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
+
+func TestBackendCheckerCachesResult(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN: a checker that has probed the fake backend once
+	backend := newFakeBackend(t, http.StatusOK)
+	checker := newStartedChecker(t, backend.URL, health.BackendCheckerConfig{
+		Interval:         noBackgroundProbes,
+		FailureThreshold: 1,
+		Policy:           health.BackendPolicyDown,
+	})
+
+	// WHEN: checking the backend repeatedly
+	for range 5 {
+		status, _ := checker.Check(t.Context())
+		testastic.Equal(t, vital.StatusOK, status)
+	}
+
+	// THEN: the backend was only probed once by Start
+	testastic.Equal(t, int32(1), backend.probes.Load())
+}
+
+func TestBackendCheckerFailsUntilFirstProbe(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN: a backend that answers the first probe only when released
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		<-release
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	checker, err := health.NewBackendChecker("backend", backend.URL+"/instance/info", "",
+		health.BackendCheckerConfig{
+			Interval:         noBackgroundProbes,
+			FailureThreshold: 1,
+			Policy:           health.BackendPolicyDegraded,
+		},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	testastic.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// WHEN: starting the checker
+	checker.Start(ctx)
+
+	// THEN: Start returns right away and readiness fails until the first probe has finished
+	status, message := checker.Check(t.Context())
+	testastic.Equal(t, vital.StatusError, status)
+	testastic.Equal(t, "waiting for the first probe", message)
+
+	close(release)
+	<-checker.Probed()
+
+	status, _ = checker.Check(t.Context())
+	testastic.Equal(t, vital.StatusOK, status)
+}
+
+func TestBackendCheckerProbesInBackground(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN: a checker probing a healthy fake backend every few milliseconds
+	backend := newFakeBackend(t, http.StatusOK)
+	checker := newStartedChecker(t, backend.URL, health.BackendCheckerConfig{
+		Interval:         5 * time.Millisecond,
+		FailureThreshold: 2,
+		Policy:           health.BackendPolicyDown,
+	})
+
+	// WHEN: the backend starts failing
+	backend.status.Store(http.StatusServiceUnavailable)
+
+	// THEN: the checker reports an error once the failure threshold is reached
+	waitForStatus(t, checker, vital.StatusError)
+
+	// WHEN: the backend recovers
+	backend.status.Store(http.StatusOK)
+
+	// THEN: the checker is ok again after the next successful probe
+	waitForStatus(t, checker, vital.StatusOK)
+
+	_, message := checker.Check(t.Context())
+	testastic.Equal(t, "", message)
+}
+
+func TestBackendCheckerHealthURL(t *testing.T) {
+	t.Parallel()
+
+	cfg := health.BackendCheckerConfig{
+		Interval:         noBackgroundProbes,
+		FailureThreshold: 1,
+		Policy:           health.BackendPolicyDown,
+	}
+
+	t.Run("health URL honors the backend path prefix", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend exposed under /api
+		backend := newFakeBackendAt(t, "/api/health/ready", http.StatusOK)
+
+		// WHEN: the checker derives the health URL from the prefixed instance URL
+		checker := newStartedCheckerWithHealthURL(t, backend.URL+"/api/instance/info", "", cfg)
+
+		// THEN: the prefixed health endpoint is probed
+		status, message := checker.Check(t.Context())
+		testastic.Equal(t, vital.StatusOK, status)
+		testastic.Equal(t, "", message)
+		testastic.Equal(t, int32(1), backend.probes.Load())
+	})
+
+	t.Run("explicit health URL is used as is", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend with a custom readiness path
+		backend := newFakeBackendAt(t, "/readyz", http.StatusOK)
+
+		// WHEN: the checker is given an explicit health URL
+		checker := newStartedCheckerWithHealthURL(t, backend.URL+"/instance/info", backend.URL+"/readyz", cfg)
+
+		// THEN: the explicit health endpoint is probed
+		status, message := checker.Check(t.Context())
+		testastic.Equal(t, vital.StatusOK, status)
+		testastic.Equal(t, "", message)
+		testastic.Equal(t, int32(1), backend.probes.Load())
+	})
+}
+
+func waitForStatus(t *testing.T, checker *health.BackendChecker, expected vital.Status) {
+	t.Helper()
+
+	deadline := time.Now().Add(eventuallyTimeout)
+	for time.Now().Before(deadline) {
+		status, _ := checker.Check(t.Context())
+		if status == expected {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("backend checker did not report %q within %s", expected, eventuallyTimeout)
+}
@@ -4,6 +4,7 @@
 package instanceapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -14,13 +15,66 @@ import (
 	"time"
 )
 
+// CpuPressureRequest defines model for cpu_pressure_request.
+type CpuPressureRequest struct {
+	// Cores Number of cores to keep busy
+	Cores int `json:"cores"`
+
+	// Duration How long to keep the cores busy
+	Duration string `json:"duration"`
+}
+
+// EchoResponse defines model for echo_response.
+type EchoResponse struct {
+	// ClientIp Originating client IP, the first X-Forwarded-For entry or the peer IP
+	ClientIp string `json:"client_ip"`
+
+	// ForwardedFor X-Forwarded-For chain from the originating client to the last proxy
+	ForwardedFor []string `json:"forwarded_for"`
+
+	// ForwardedHost Value of the X-Forwarded-Host header
+	ForwardedHost *string `json:"forwarded_host,omitempty"`
+
+	// ForwardedPort Value of the X-Forwarded-Port header
+	ForwardedPort *string `json:"forwarded_port,omitempty"`
+
+	// ForwardedProto Value of the X-Forwarded-Proto header
+	ForwardedProto *string `json:"forwarded_proto,omitempty"`
+
+	// Headers Request headers by canonical name, with sensitive values redacted
+	Headers  map[string][]string `json:"headers"`
+	Instance InstanceIdentity    `json:"instance"`
+
+	// Method HTTP method of the request
+	Method string `json:"method"`
+
+	// Path Request path as received by the instance
+	Path string `json:"path"`
+
+	// Query Query parameters by name
+	Query map[string][]string `json:"query"`
+
+	// RemoteAddr Network address of the immediate peer, usually the last proxy
+	RemoteAddr string `json:"remote_addr"`
+}
+
+// InstanceIdentity defines model for instance_identity.
+type InstanceIdentity struct {
+	// Hostname Instance hostname
+	Hostname string `json:"hostname"`
+
+	// Version Application version
+	Version string `json:"version"`
+}
+
 // InstanceInfoResponse defines model for instance_info_response.
 type InstanceInfoResponse struct {
 	// GoVersion Go runtime version
 	GoVersion string `json:"go_version"`
 
 	// Hostname Instance hostname
-	Hostname string `json:"hostname"`
+	Hostname string         `json:"hostname"`
+	Pressure PressureStatus `json:"pressure"`
 
 	// Timestamp Current server timestamp
 	Timestamp time.Time `json:"timestamp"`
@@ -32,6 +86,60 @@ type InstanceInfoResponse struct {
 	Version string `json:"version"`
 }
 
+// MemoryPressureRequest defines model for memory_pressure_request.
+type MemoryPressureRequest struct {
+	// Duration How long to retain the memory
+	Duration string `json:"duration"`
+
+	// Megabytes Amount of memory to allocate and retain in megabytes
+	Megabytes int `json:"megabytes"`
+}
+
+// PressureStatus defines model for pressure_status.
+type PressureStatus struct {
+	// CpuCores Number of cores currently kept busy
+	CpuCores int `json:"cpu_cores"`
+
+	// CpuUntil When the CPU pressure ends, omitted when inactive
+	CpuUntil *time.Time `json:"cpu_until,omitempty"`
+
+	// MemoryBytes Amount of memory currently retained in bytes
+	MemoryBytes int64 `json:"memory_bytes"`
+
+	// MemoryUntil When the retained memory is released, omitted when inactive
+	MemoryUntil *time.Time `json:"memory_until,omitempty"`
+}
+
+// ProblemDetail RFC 9457 problem details
+type ProblemDetail struct {
+	// Detail Explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
+
+	// Instance URI reference identifying this occurrence
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI reference identifying the problem type
+	Type *string `json:"type,omitempty"`
+}
+
+// BadRequest RFC 9457 problem details
+type BadRequest = ProblemDetail
+
+// PressureDisabled RFC 9457 problem details
+type PressureDisabled = ProblemDetail
+
+// StartCpuPressureJSONRequestBody defines body for StartCpuPressure for application/json ContentType.
+type StartCpuPressureJSONRequestBody = CpuPressureRequest
+
+// StartMemoryPressureJSONRequestBody defines body for StartMemoryPressure for application/json ContentType.
+type StartMemoryPressureJSONRequestBody = MemoryPressureRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -105,8 +213,36 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetInstanceEcho request
+	GetInstanceEcho(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetInstanceInfo request
 	GetInstanceInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StopPressure request
+	StopPressure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartCpuPressureWithBody request with any body
+	StartCpuPressureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartCpuPressure(ctx context.Context, body StartCpuPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartMemoryPressureWithBody request with any body
+	StartMemoryPressureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	StartMemoryPressure(ctx context.Context, body StartMemoryPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetInstanceEcho(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInstanceEchoRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) GetInstanceInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -121,6 +257,93 @@ func (c *Client) GetInstanceInfo(ctx context.Context, reqEditors ...RequestEdito
 	return c.Client.Do(req)
 }
 
+func (c *Client) StopPressure(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStopPressureRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartCpuPressureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartCpuPressureRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartCpuPressure(ctx context.Context, body StartCpuPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartCpuPressureRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartMemoryPressureWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartMemoryPressureRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartMemoryPressure(ctx context.Context, body StartMemoryPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartMemoryPressureRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetInstanceEchoRequest generates requests for GetInstanceEcho
+func NewGetInstanceEchoRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instance/echo")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetInstanceInfoRequest generates requests for GetInstanceInfo
 func NewGetInstanceInfoRequest(server string) (*http.Request, error) {
 	var err error
@@ -148,6 +371,113 @@ func NewGetInstanceInfoRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewStopPressureRequest generates requests for StopPressure
+func NewStopPressureRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instance/pressure")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStartCpuPressureRequest calls the generic StartCpuPressure builder with application/json body
+func NewStartCpuPressureRequest(server string, body StartCpuPressureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartCpuPressureRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStartCpuPressureRequestWithBody generates requests for StartCpuPressure with any type of body
+func NewStartCpuPressureRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instance/pressure/cpu")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStartMemoryPressureRequest calls the generic StartMemoryPressure builder with application/json body
+func NewStartMemoryPressureRequest(server string, body StartMemoryPressureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewStartMemoryPressureRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewStartMemoryPressureRequestWithBody generates requests for StartMemoryPressure with any type of body
+func NewStartMemoryPressureRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/instance/pressure/memory")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -191,8 +521,46 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// GetInstanceEchoWithResponse request
+	GetInstanceEchoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstanceEchoResponse, error)
+
 	// GetInstanceInfoWithResponse request
 	GetInstanceInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstanceInfoResponse, error)
+
+	// StopPressureWithResponse request
+	StopPressureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StopPressureResponse, error)
+
+	// StartCpuPressureWithBodyWithResponse request with any body
+	StartCpuPressureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartCpuPressureResponse, error)
+
+	StartCpuPressureWithResponse(ctx context.Context, body StartCpuPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*StartCpuPressureResponse, error)
+
+	// StartMemoryPressureWithBodyWithResponse request with any body
+	StartMemoryPressureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartMemoryPressureResponse, error)
+
+	StartMemoryPressureWithResponse(ctx context.Context, body StartMemoryPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*StartMemoryPressureResponse, error)
+}
+
+type GetInstanceEchoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EchoResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInstanceEchoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInstanceEchoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type GetInstanceInfoResponse struct {
@@ -217,6 +585,86 @@ func (r GetInstanceInfoResponse) StatusCode() int {
 	return 0
 }
 
+type StopPressureResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *PressureStatus
+	ApplicationproblemJSON403 *PressureDisabled
+}
+
+// Status returns HTTPResponse.Status
+func (r StopPressureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StopPressureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartCpuPressureResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *PressureStatus
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON403 *PressureDisabled
+}
+
+// Status returns HTTPResponse.Status
+func (r StartCpuPressureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartCpuPressureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartMemoryPressureResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *PressureStatus
+	ApplicationproblemJSON400 *BadRequest
+	ApplicationproblemJSON403 *PressureDisabled
+}
+
+// Status returns HTTPResponse.Status
+func (r StartMemoryPressureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartMemoryPressureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetInstanceEchoWithResponse request returning *GetInstanceEchoResponse
+func (c *ClientWithResponses) GetInstanceEchoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstanceEchoResponse, error) {
+	rsp, err := c.GetInstanceEcho(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInstanceEchoResponse(rsp)
+}
+
 // GetInstanceInfoWithResponse request returning *GetInstanceInfoResponse
 func (c *ClientWithResponses) GetInstanceInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetInstanceInfoResponse, error) {
 	rsp, err := c.GetInstanceInfo(ctx, reqEditors...)
@@ -226,6 +674,75 @@ func (c *ClientWithResponses) GetInstanceInfoWithResponse(ctx context.Context, r
 	return ParseGetInstanceInfoResponse(rsp)
 }
 
+// StopPressureWithResponse request returning *StopPressureResponse
+func (c *ClientWithResponses) StopPressureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StopPressureResponse, error) {
+	rsp, err := c.StopPressure(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStopPressureResponse(rsp)
+}
+
+// StartCpuPressureWithBodyWithResponse request with arbitrary body returning *StartCpuPressureResponse
+func (c *ClientWithResponses) StartCpuPressureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartCpuPressureResponse, error) {
+	rsp, err := c.StartCpuPressureWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartCpuPressureResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartCpuPressureWithResponse(ctx context.Context, body StartCpuPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*StartCpuPressureResponse, error) {
+	rsp, err := c.StartCpuPressure(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartCpuPressureResponse(rsp)
+}
+
+// StartMemoryPressureWithBodyWithResponse request with arbitrary body returning *StartMemoryPressureResponse
+func (c *ClientWithResponses) StartMemoryPressureWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartMemoryPressureResponse, error) {
+	rsp, err := c.StartMemoryPressureWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartMemoryPressureResponse(rsp)
+}
+
+func (c *ClientWithResponses) StartMemoryPressureWithResponse(ctx context.Context, body StartMemoryPressureJSONRequestBody, reqEditors ...RequestEditorFn) (*StartMemoryPressureResponse, error) {
+	rsp, err := c.StartMemoryPressure(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartMemoryPressureResponse(rsp)
+}
+
+// ParseGetInstanceEchoResponse parses an HTTP response from a GetInstanceEchoWithResponse call
+func ParseGetInstanceEchoResponse(rsp *http.Response) (*GetInstanceEchoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInstanceEchoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EchoResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetInstanceInfoResponse parses an HTTP response from a GetInstanceInfoWithResponse call
 func ParseGetInstanceInfoResponse(rsp *http.Response) (*GetInstanceInfoResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -251,3 +768,116 @@ func ParseGetInstanceInfoResponse(rsp *http.Response) (*GetInstanceInfoResponse,
 
 	return response, nil
 }
+
+// ParseStopPressureResponse parses an HTTP response from a StopPressureWithResponse call
+func ParseStopPressureResponse(rsp *http.Response) (*StopPressureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StopPressureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PressureStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest PressureDisabled
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartCpuPressureResponse parses an HTTP response from a StartCpuPressureWithResponse call
+func ParseStartCpuPressureResponse(rsp *http.Response) (*StartCpuPressureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartCpuPressureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PressureStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest PressureDisabled
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartMemoryPressureResponse parses an HTTP response from a StartMemoryPressureWithResponse call
+func ParseStartMemoryPressureResponse(rsp *http.Response) (*StartMemoryPressureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartMemoryPressureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PressureStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest PressureDisabled
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON403 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,52 @@
+package rollout
+
+import (
+	"context"
+	"phasor/frontend/internal/frontend"
+	"sync"
+	"time"
+)
+
+// KubernetesCacheTTL is how long a rollout status read from the Kubernetes API is reused.
+// The dashboard polls /tiles every few seconds per open browser tab, so without the cache
+// every tab would call the API server on every poll.
+const KubernetesCacheTTL = 5 * time.Second
+
+// CachedSource reuses the status of another source for a fixed time. Failures are cached as
+// well, so an unreachable API server is not called on every request either. Concurrent calls
+// after the status expired wait for a single fetch.
+type CachedSource struct {
+	source frontend.RolloutStatusSource
+	ttl    time.Duration
+	now    frontend.ClockFunc
+
+	mu        sync.Mutex
+	fetchedAt time.Time
+	status    frontend.RolloutStatus
+	err       error
+}
+
+// NewCachedSource creates a new rollout status source that caches the status of source for ttl.
+func NewCachedSource(source frontend.RolloutStatusSource, ttl time.Duration, now frontend.ClockFunc) *CachedSource {
+	return &CachedSource{
+		source: source,
+		ttl:    ttl,
+		now:    now,
+	}
+}
+
+// RolloutStatus returns the cached rollout status, fetching it from the source once it expired.
+func (s *CachedSource) RolloutStatus(ctx context.Context) (frontend.RolloutStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !s.fetchedAt.IsZero() && now.Sub(s.fetchedAt) < s.ttl {
+		return s.status, s.err
+	}
+
+	status, err := s.source.RolloutStatus(ctx)
+	s.status, s.err, s.fetchedAt = status, err, now
+
+	return status, err //nolint:wrapcheck // The cache is transparent, the source wraps its errors.
+}
@@ -0,0 +1,73 @@
+package rollout_test
+
+import (
+	"context"
+	"errors"
+	"phasor/frontend/internal/frontend"
+	"phasor/frontend/internal/rollout"
+	"testing"
+	"time"
+
+	"github.com/monkescience/testastic"
+)
+
+const testCacheTTL = 5 * time.Second
+
+var errAPIUnavailable = errors.New("api server unavailable")
+
+// countingSource returns the current step as the number of calls so far, or err if set.
+type countingSource struct {
+	calls int
+	err   error
+}
+
+func (s *countingSource) RolloutStatus(_ context.Context) (frontend.RolloutStatus, error) {
+	s.calls++
+
+	return frontend.RolloutStatus{CurrentStep: s.calls}, s.err
+}
+
+func TestCachedSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("status is fetched again once it expired", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a cached source and a fake clock
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		source := &countingSource{}
+		cached := rollout.NewCachedSource(source, testCacheTTL, func() time.Time { return now })
+
+		// WHEN: reading the status repeatedly within the TTL and once after it
+		first, _ := cached.RolloutStatus(t.Context())
+		now = now.Add(testCacheTTL - time.Second)
+		cachedStatus, _ := cached.RolloutStatus(t.Context())
+		now = now.Add(time.Second)
+		expired, err := cached.RolloutStatus(t.Context())
+
+		// THEN: the source is only called again after the TTL
+		testastic.NoError(t, err)
+		testastic.Equal(t, 1, first.CurrentStep)
+		testastic.Equal(t, 1, cachedStatus.CurrentStep)
+		testastic.Equal(t, 2, expired.CurrentStep)
+		testastic.Equal(t, 2, source.calls)
+	})
+
+	t.Run("failures are cached", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a cached source whose API server is unavailable
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		source := &countingSource{err: errAPIUnavailable}
+		cached := rollout.NewCachedSource(source, testCacheTTL, func() time.Time { return now })
+
+		// WHEN: reading the status twice within the TTL
+		_, firstErr := cached.RolloutStatus(t.Context())
+		_, secondErr := cached.RolloutStatus(t.Context())
+
+		// THEN: the failure is reported both times, but the API server is only called once
+		testastic.ErrorIs(t, firstErr, errAPIUnavailable)
+		testastic.ErrorIs(t, secondErr, errAPIUnavailable)
+		testastic.Equal(t, 1, source.calls)
+	})
+}
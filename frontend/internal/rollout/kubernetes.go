@@ -0,0 +1,230 @@
+package rollout
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"phasor/frontend/internal/frontend"
+	"strings"
+	"time"
+)
+
+const (
+	inClusterAPIServer    = "https://kubernetes.default.svc"
+	serviceAccountDir     = "/var/run/secrets/kubernetes.io/serviceaccount"
+	kubernetesAPITimeout  = 3 * time.Second
+	fullyPromotedPercent  = 100
+	rolloutsAPIPathFormat = "/apis/argoproj.io/v1alpha1/namespaces/%s/rollouts/%s"
+)
+
+// ErrInvalidCACertificate is returned when the service account CA certificate cannot be parsed.
+var ErrInvalidCACertificate = errors.New("failed to parse service account CA certificate")
+
+// KubernetesSource reads the status of an Argo Rollout from the Kubernetes API. It talks to the
+// REST API directly so the frontend does not depend on client-go.
+type KubernetesSource struct {
+	client    *http.Client
+	apiServer string
+	namespace string
+	name      string
+	token     string
+	tokenFile string
+}
+
+// NewKubernetesSource creates a new rollout status source for the Rollout name in namespace,
+// authenticating to the API server with the bearer token.
+func NewKubernetesSource(apiServer, namespace, name, token string, client *http.Client) *KubernetesSource {
+	return &KubernetesSource{
+		client:    client,
+		apiServer: strings.TrimSuffix(apiServer, "/"),
+		namespace: namespace,
+		name:      name,
+		token:     token,
+	}
+}
+
+// NewInClusterSource creates a new rollout status source using the pod's service account.
+// The namespace defaults to the pod's namespace. The token is re-read on every request so
+// that rotated service account tokens are picked up.
+func NewInClusterSource(namespace, name string) (*KubernetesSource, error) {
+	caCert, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA certificate: %w", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caCert) {
+		return nil, ErrInvalidCACertificate
+	}
+
+	if namespace == "" {
+		podNamespace, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account namespace: %w", err)
+		}
+
+		namespace = strings.TrimSpace(string(podNamespace))
+	}
+
+	client := &http.Client{
+		Timeout: kubernetesAPITimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	source := NewKubernetesSource(inClusterAPIServer, namespace, name, "", client)
+	source.tokenFile = filepath.Join(serviceAccountDir, "token")
+
+	return source, nil
+}
+
+// RolloutStatus fetches the Rollout resource and derives the intended canary weight from
+// its status and canary steps.
+func (s *KubernetesSource) RolloutStatus(ctx context.Context) (frontend.RolloutStatus, error) {
+	token, err := s.bearerToken()
+	if err != nil {
+		return frontend.RolloutStatus{}, err
+	}
+
+	url := s.apiServer + fmt.Sprintf(rolloutsAPIPathFormat, s.namespace, s.name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to fetch rollout: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return frontend.RolloutStatus{}, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	var rollout rolloutResource
+
+	err = json.NewDecoder(resp.Body).Decode(&rollout)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to decode rollout: %w", err)
+	}
+
+	return rollout.toStatus(), nil
+}
+
+func (s *KubernetesSource) bearerToken() (string, error) {
+	if s.tokenFile == "" {
+		return s.token, nil
+	}
+
+	token, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// rolloutResource holds the fields of an argoproj.io/v1alpha1 Rollout that the dashboard needs.
+//
+//nolint:tagliatelle // Field names are defined by the Argo Rollouts API.
+type rolloutResource struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Strategy struct {
+			Canary *struct {
+				Steps []struct {
+					SetWeight *int `json:"setWeight"`
+				} `json:"steps"`
+			} `json:"canary"`
+		} `json:"strategy"`
+	} `json:"spec"`
+	Status struct {
+		Phase            string `json:"phase"`
+		CurrentStepIndex *int   `json:"currentStepIndex"`
+		StableRS         string `json:"stableRS"`
+		CurrentPodHash   string `json:"currentPodHash"`
+		Canary           struct {
+			Weights *struct {
+				Canary struct {
+					Weight int `json:"weight"`
+				} `json:"canary"`
+			} `json:"weights"`
+		} `json:"canary"`
+	} `json:"status"`
+}
+
+// toStatus derives the intended rollout status. With traffic routing, Argo reports the canary
+// weight directly; otherwise it is the last setWeight up to the current step.
+func (r *rolloutResource) toStatus() frontend.RolloutStatus {
+	status := frontend.RolloutStatus{
+		Name:       r.Metadata.Name,
+		Phase:      r.Status.Phase,
+		StableHash: r.Status.StableRS,
+	}
+
+	if r.Status.CurrentPodHash == "" || r.Status.CurrentPodHash == r.Status.StableRS {
+		// Fully promoted: there is no canary ReplicaSet.
+		if r.Spec.Strategy.Canary != nil {
+			status.TotalSteps = len(r.Spec.Strategy.Canary.Steps)
+			status.CurrentStep = status.TotalSteps
+		}
+
+		return status
+	}
+
+	status.CanaryHash = r.Status.CurrentPodHash
+
+	if r.Spec.Strategy.Canary == nil {
+		return status
+	}
+
+	steps := r.Spec.Strategy.Canary.Steps
+	status.TotalSteps = len(steps)
+
+	if r.Status.CurrentStepIndex != nil {
+		status.CurrentStep = min(*r.Status.CurrentStepIndex, len(steps))
+	}
+
+	if r.Status.Canary.Weights != nil {
+		status.CanaryWeight = r.Status.Canary.Weights.Canary.Weight
+
+		return status
+	}
+
+	if status.CurrentStep >= len(steps) {
+		status.CanaryWeight = fullyPromotedPercent
+
+		return status
+	}
+
+	for _, step := range steps[:status.CurrentStep+1] {
+		if step.SetWeight != nil {
+			status.CanaryWeight = *step.SetWeight
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,137 @@
+package rollout_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"phasor/frontend/internal/frontend"
+	"phasor/frontend/internal/rollout"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+const (
+	testToken       = "test-token"
+	testRolloutPath = "/apis/argoproj.io/v1alpha1/namespaces/demo/rollouts/phasor-backend"
+)
+
+// canarySpec is a Rollout spec with the canary steps 20%, pause, 40%, pause, 100%.
+const canarySpec = `"spec": {"strategy": {"canary": {"steps": [
+	{"setWeight": 20}, {"pause": {}},
+	{"setWeight": 40}, {"pause": {"duration": "1m"}},
+	{"setWeight": 100}
+]}}}`
+
+// newFakeAPIServer serves the given Rollout resource to requests carrying the test token.
+func newFakeAPIServer(t *testing.T, resource string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer "+testToken {
+			writer.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if req.URL.Path != testRolloutPath {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(resource))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func fetchStatus(t *testing.T, resource, token string) (frontend.RolloutStatus, error) {
+	t.Helper()
+
+	server := newFakeAPIServer(t, resource)
+	source := rollout.NewKubernetesSource(server.URL, "demo", "phasor-backend", token, server.Client())
+
+	return source.RolloutStatus(context.Background())
+}
+
+func TestKubernetesSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("paused step reports the last set weight", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a rollout paused after setting the canary weight to 40%
+		resource := `{"metadata": {"name": "phasor-backend"}, ` + canarySpec + `, "status": {
+			"phase": "Paused", "currentStepIndex": 3, "stableRS": "aaaa", "currentPodHash": "bbbb"
+		}}`
+
+		// WHEN: fetching the rollout status
+		status, err := fetchStatus(t, resource, testToken)
+
+		// THEN: the intended weight and both pod template hashes are reported
+		testastic.NoError(t, err)
+		testastic.Equal(t, frontend.RolloutStatus{
+			Name:         "phasor-backend",
+			Phase:        "Paused",
+			CurrentStep:  3,
+			TotalSteps:   5,
+			CanaryWeight: 40,
+			StableHash:   "aaaa",
+			CanaryHash:   "bbbb",
+		}, status)
+	})
+
+	t.Run("traffic routing weight takes precedence over steps", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a rollout using traffic routing that reports its actual canary weight
+		resource := `{"metadata": {"name": "phasor-backend"}, ` + canarySpec + `, "status": {
+			"phase": "Progressing", "currentStepIndex": 2, "stableRS": "aaaa", "currentPodHash": "bbbb",
+			"canary": {"weights": {"canary": {"weight": 35}, "stable": {"weight": 65}}}
+		}}`
+
+		// WHEN: fetching the rollout status
+		status, err := fetchStatus(t, resource, testToken)
+
+		// THEN: the weight reported by Argo Rollouts is used
+		testastic.NoError(t, err)
+		testastic.Equal(t, 35, status.CanaryWeight)
+		testastic.Equal(t, 2, status.CurrentStep)
+	})
+
+	t.Run("fully promoted rollout has no canary", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a rollout whose current pods are the stable ReplicaSet
+		resource := `{"metadata": {"name": "phasor-backend"}, ` + canarySpec + `, "status": {
+			"phase": "Healthy", "currentStepIndex": 5, "stableRS": "bbbb", "currentPodHash": "bbbb"
+		}}`
+
+		// WHEN: fetching the rollout status
+		status, err := fetchStatus(t, resource, testToken)
+
+		// THEN: all steps are complete and no canary hash is reported
+		testastic.NoError(t, err)
+		testastic.Equal(t, "", status.CanaryHash)
+		testastic.Equal(t, 0, status.CanaryWeight)
+		testastic.Equal(t, 5, status.CurrentStep)
+		testastic.Equal(t, 5, status.TotalSteps)
+	})
+
+	t.Run("rejected token is reported as an error", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a source with a token the API server rejects
+		resource := `{"metadata": {"name": "phasor-backend"}}`
+
+		// WHEN: fetching the rollout status
+		_, err := fetchStatus(t, resource, "wrong-token")
+
+		// THEN: the unexpected status code is returned
+		testastic.Equal(t, true, errors.Is(err, rollout.ErrUnexpectedStatusCode))
+	})
+}
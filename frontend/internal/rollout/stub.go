@@ -0,0 +1,92 @@
+// Package rollout provides sources for the intended status of an Argo Rollout.
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"phasor/frontend/internal/frontend"
+	"time"
+)
+
+const httpSourceTimeout = 3 * time.Second
+
+// ErrUnexpectedStatusCode is returned when a rollout status endpoint returns a non-200 status code.
+var ErrUnexpectedStatusCode = errors.New("unexpected status code from rollout status endpoint")
+
+// FileSource reads the rollout status from a JSON file on every call. It is meant for tests
+// and demos without a cluster; the file can be changed while the frontend is running.
+type FileSource struct {
+	path string
+}
+
+// NewFileSource creates a new rollout status source reading the JSON file at path.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: filepath.Clean(path)}
+}
+
+// RolloutStatus returns the rollout status stored in the file.
+func (s *FileSource) RolloutStatus(_ context.Context) (frontend.RolloutStatus, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to read rollout status file: %w", err)
+	}
+
+	var status frontend.RolloutStatus
+
+	err = json.Unmarshal(data, &status)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to decode rollout status file: %w", err)
+	}
+
+	return status, nil
+}
+
+// HTTPSource fetches the rollout status as JSON from a URL on every call, e.g. from a stub
+// server in tests.
+type HTTPSource struct {
+	client *http.Client
+	url    string
+}
+
+// NewHTTPSource creates a new rollout status source fetching JSON from url.
+func NewHTTPSource(url string) *HTTPSource {
+	return &HTTPSource{
+		client: &http.Client{Timeout: httpSourceTimeout},
+		url:    url,
+	}
+}
+
+// RolloutStatus returns the rollout status served at the URL.
+func (s *HTTPSource) RolloutStatus(ctx context.Context) (frontend.RolloutStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to fetch rollout status: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return frontend.RolloutStatus{}, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	var status frontend.RolloutStatus
+
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return frontend.RolloutStatus{}, fmt.Errorf("failed to decode rollout status: %w", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,158 @@
+package testutil
+
+import (
+	"fmt"
+	"log/slog"
+	"phasor/frontend/internal/config"
+	"time"
+)
+
+// Option configures a test server created by NewTestServer.
+type Option func(*settings)
+
+// settings holds everything an Option may change about a test server.
+type settings struct {
+	cfg      *config.Config
+	hostname string
+	now      func() time.Time
+	logger   *slog.Logger
+}
+
+func newSettings(opts []Option) *settings {
+	cfg := &config.Config{
+		Version:     testVersion,
+		Environment: "test",
+	}
+	// A single failed probe marks a backend down, so that tests need not wait for several.
+	cfg.BackendHealth.FailureThreshold = 1
+	cfg.ApplyDefaults()
+
+	s := &settings{
+		cfg:      cfg,
+		hostname: testHostname,
+		now:      time.Now,
+		logger:   slog.New(slog.DiscardHandler),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithVersion sets the version the server reports, "test-version" by default.
+func WithVersion(version string) Option {
+	return func(s *settings) {
+		s.cfg.Version = version
+	}
+}
+
+// WithHostname sets the hostname the server reports, "test-host" by default, e.g. the pod
+// name of a blue or green frontend.
+func WithHostname(hostname string) Option {
+	return func(s *settings) {
+		s.hostname = hostname
+	}
+}
+
+// WithEnvironment sets the environment the health endpoints report, "test" by default.
+func WithEnvironment(environment string) Option {
+	return func(s *settings) {
+		s.cfg.Environment = environment
+	}
+}
+
+// WithClock makes the server read the current time from now, e.g. Clock.Now, so timestamps
+// and uptimes can be asserted exactly.
+func WithClock(now func() time.Time) Option {
+	return func(s *settings) {
+		s.now = now
+	}
+}
+
+// WithBackendURLs sets the instance API URLs the dashboard samples. A single URL is sampled
+// as the backend, several URLs side by side as the targets "backend-1", "backend-2" and so on.
+func WithBackendURLs(urls ...string) Option {
+	return func(s *settings) {
+		if len(urls) == 1 {
+			s.cfg.BackendURL = urls[0]
+
+			return
+		}
+
+		targets := make([]Target, len(urls))
+		for i, url := range urls {
+			targets[i] = Target{Name: fmt.Sprintf("backend-%d", i+1), URL: url}
+		}
+
+		s.cfg.Targets = targets
+	}
+}
+
+// WithTargets sets the named instance API URLs the dashboard samples side by side.
+func WithTargets(targets ...Target) Option {
+	return func(s *settings) {
+		s.cfg.Targets = targets
+	}
+}
+
+// WithTileColors sets custom tile colors, overriding the palette.
+func WithTileColors(colors ...string) Option {
+	return func(s *settings) {
+		s.cfg.TileColors = colors
+	}
+}
+
+// WithColors colors tiles from the named palette in the given color mode.
+func WithColors(palette, mode string) Option {
+	return func(s *settings) {
+		s.cfg.Colors.Palette = palette
+		s.cfg.Colors.Mode = mode
+	}
+}
+
+// WithTemplates serves the templates in dir instead of the embedded ones, so tests can assert
+// on minimal markup.
+func WithTemplates(dir string) Option {
+	return func(s *settings) {
+		s.cfg.TemplatesDir = dir
+	}
+}
+
+// WithHeaderProfiles makes the given header profiles selectable on the index page.
+func WithHeaderProfiles(profiles ...HeaderProfile) Option {
+	return func(s *settings) {
+		s.cfg.HeaderProfiles = profiles
+	}
+}
+
+// WithPreview serves the blue-green preview page comparing the frontends at activeURL and previewURL.
+func WithPreview(activeURL, previewURL string) Option {
+	return func(s *settings) {
+		s.cfg.Preview.ActiveURL = activeURL
+		s.cfg.Preview.PreviewURL = previewURL
+	}
+}
+
+// WithRolloutStatusFile shows the rollout status read from the JSON file at path next to the tiles.
+func WithRolloutStatusFile(path string) Option {
+	return func(s *settings) {
+		s.cfg.RolloutStatus.Source = "file"
+		s.cfg.RolloutStatus.File = path
+	}
+}
+
+// WithSecurityHeaders sets the given security headers instead of the defaults.
+func WithSecurityHeaders(headers SecurityHeaders) Option {
+	return func(s *settings) {
+		s.cfg.SecurityHeaders = headers
+	}
+}
+
+// WithLogger sets the logger of the server, which discards all logs by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *settings) {
+		s.logger = logger
+	}
+}
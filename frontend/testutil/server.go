@@ -2,31 +2,94 @@
 package testutil
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http/httptest"
 	"phasor/frontend/internal/app"
 	"phasor/frontend/internal/config"
+	"phasor/shared/lifecycle"
+	"time"
+
+	"github.com/monkescience/vital"
+
+	sharedtestutil "phasor/shared/testutil"
 )
 
+const (
+	testShutdownTimeout = 5 * time.Second
+	testVersion         = "test-version"
+	testHostname        = "test-host"
+)
+
+// Server is a test server that can be shut down using the production drain sequence.
+type Server struct {
+	*httptest.Server
+
+	server          *vital.Server
+	shutdownChecker *lifecycle.ShutdownChecker
+	logger          *slog.Logger
+	stop            context.CancelFunc
+}
+
+// HeaderProfile is a named set of request headers attached to backend requests.
+type HeaderProfile = config.HeaderProfile
+
+// SecurityHeaders holds the values of the security headers, empty values omit the header.
+type SecurityHeaders = config.SecurityHeaders
+
+// DefaultSecurityHeaders returns the security headers used by NewTestServer.
+func DefaultSecurityHeaders() SecurityHeaders {
+	return config.DefaultSecurityHeaders()
+}
+
+// Target is a named backend instance API URL sampled by the dashboard.
+type Target = config.Target
+
 // NewTestServer creates a fully configured test server with the same middleware
-// and routing as production. Returns an httptest.Server ready for integration tests.
-func NewTestServer(
-	backendURL string,
-	tileColors []string,
-	templatesPath string,
-	logger *slog.Logger,
-) (*httptest.Server, error) {
-	cfg := &config.Config{
-		BackendURL:  backendURL,
-		Environment: "test",
-		TileColors:  tileColors,
-	}
+// and routing as production. Returns a Server ready for integration tests.
+// Without options it reports version "test-version" and the fixed hostname "test-host"
+// for deterministic test output, and serves the templates embedded in the binary.
+func NewTestServer(opts ...Option) (*Server, error) {
+	s := newSettings(opts)
+	shutdownChecker := lifecycle.NewShutdownChecker()
+	ctx, stop := context.WithCancel(context.Background())
+	hostname := s.hostname
 
-	router, err := app.SetupRouter(cfg, templatesPath, logger)
+	router, err := app.SetupRouterWithHostnameAndClock(
+		ctx,
+		s.cfg,
+		shutdownChecker,
+		s.logger,
+		func() string { return hostname },
+		s.now,
+	)
 	if err != nil {
+		stop()
+
 		return nil, fmt.Errorf("failed to setup router: %w", err)
 	}
 
-	return httptest.NewServer(router), nil
+	server := vital.NewServer(router, vital.WithShutdownTimeout(testShutdownTimeout), vital.WithLogger(s.logger))
+
+	return &Server{
+		Server:          sharedtestutil.StartServer(server),
+		server:          server,
+		shutdownChecker: shutdownChecker,
+		logger:          s.logger,
+		stop:            stop,
+	}, nil
+}
+
+// Shutdown runs the production graceful shutdown sequence: readiness starts failing,
+// the server keeps serving for drainPeriod, and is then shut down.
+func (s *Server) Shutdown(drainPeriod time.Duration) error {
+	//nolint:wrapcheck // The error is already wrapped by Drain.
+	return lifecycle.Drain(s.server, s.shutdownChecker, drainPeriod, s.logger)
+}
+
+// Close shuts down the server and stops the background backend health probes.
+func (s *Server) Close() {
+	s.stop()
+	s.Server.Close()
 }
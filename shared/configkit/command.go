@@ -0,0 +1,146 @@
+package configkit
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Commands run instead of the server, e.g. to check a rendered ConfigMap before deploying it.
+const (
+	// CommandValidate loads and validates the config file alone. Values that are only read
+	// from the environment are checked at server start, so they need not be set.
+	CommandValidate = "validate"
+	// CommandPrintEffective prints the config with defaults and environment variables applied.
+	CommandPrintEffective = "print-effective"
+	// CommandSchema prints the JSON Schema of the config file.
+	CommandSchema = "schema"
+)
+
+const yamlIndent = 2
+
+// ErrUnknownCommand is returned for a command other than validate, print-effective or schema.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Commands runs the config commands of a service whose config has the type C.
+type Commands[C any] struct {
+	// Load loads and validates the config file at path, or the defaults for an empty path,
+	// exactly like at server start.
+	Load func(path string) (*C, error)
+	// LoadFile loads and validates only the config file at path, like Load without the values
+	// that are only read from the environment.
+	LoadFile func(path string) (*C, error)
+	// JSONSchema returns the JSON Schema of the config file.
+	JSONSchema func() ([]byte, error)
+	// EnvComments returns the lines print-effective writes as comments above the config,
+	// for values that are only read from the environment and are not part of the YAML.
+	EnvComments func(cfg *C) []string
+}
+
+// Run runs the named command with its arguments and writes its output to stdout. configPath
+// is the default of the command's -config flag, which may be relative or empty for the defaults.
+func (c Commands[C]) Run(name string, args []string, configPath string, stdout io.Writer) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := flags.String("config", configPath, "Path to the configuration file")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	switch name {
+	case CommandValidate:
+		source, _, err := c.load(c.LoadFile, *path)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(stdout, "%s: valid\n", source)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		return nil
+	case CommandPrintEffective:
+		source, cfg, err := c.load(c.Load, *path)
+		if err != nil {
+			return err
+		}
+
+		return c.printEffective(stdout, source, cfg)
+	case CommandSchema:
+		schema, err := c.JSONSchema()
+		if err != nil {
+			return fmt.Errorf("failed to generate schema: %w", err)
+		}
+
+		_, err = stdout.Write(schema)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w %q (must be %s, %s or %s)",
+			ErrUnknownCommand, name, CommandValidate, CommandPrintEffective, CommandSchema)
+	}
+}
+
+// load loads the config file at path, which may be relative to the working directory, with
+// loadFunc. It returns the absolute path, or a description of the defaults for an empty path.
+func (c Commands[C]) load(loadFunc func(string) (*C, error), path string) (string, *C, error) {
+	source := "built-in defaults"
+
+	if path != "" {
+		var err error
+
+		path, err = filepath.Abs(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to resolve config path: %w", err)
+		}
+
+		source = path
+	}
+
+	cfg, err := loadFunc(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return source, cfg, nil
+}
+
+// printEffective writes cfg as YAML below comments naming its source and the values that are
+// only read from the environment.
+func (c Commands[C]) printEffective(stdout io.Writer, source string, cfg *C) error {
+	_, err := fmt.Fprintf(stdout, "# Effective config of %s\n", source)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	for _, comment := range c.EnvComments(cfg) {
+		_, err = fmt.Fprintf(stdout, "# %s\n", comment)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	encoder := yaml.NewEncoder(stdout)
+	encoder.SetIndent(yamlIndent)
+
+	err = encoder.Encode(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return nil
+}
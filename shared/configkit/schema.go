@@ -0,0 +1,97 @@
+package configkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// durationPattern matches the Go durations accepted by time.ParseDuration, e.g. "1m30s".
+	durationPattern = `^-?(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+)
+
+// JSONSchema returns the JSON Schema of a YAML config file decoded into a value of type t.
+// enums maps YAML paths to the allowed values of string fields, which should be the same
+// values the config's validation accepts.
+func JSONSchema(t reflect.Type, title string, enums map[string][]string) ([]byte, error) {
+	generator := schemaGenerator{enums: enums}
+
+	schema := generator.typeSchema(t, "")
+	schema["$schema"] = schemaDialect
+	schema["title"] = title
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+type schemaGenerator struct {
+	enums map[string][]string
+}
+
+// typeSchema returns the schema of a value of type t at the YAML path. A pointer, which
+// tells an explicit zero from an unset field, has the schema of its element.
+func (g schemaGenerator) typeSchema(t reflect.Type, path string) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeFor[time.Duration]() {
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Configs only use these kinds.
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem(), path+"[]")}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem(), path+".*")}
+	case reflect.Struct:
+		return g.structSchema(t, path)
+	default:
+		schema := map[string]any{"type": "string"}
+		if values, ok := g.enums[path]; ok {
+			schema["enum"] = values
+		}
+
+		return schema
+	}
+}
+
+// structSchema returns the schema of a struct, with a property per YAML field. Unknown
+// properties are rejected, like the services' config loaders do.
+func (g schemaGenerator) structSchema(t reflect.Type, path string) map[string]any {
+	properties := make(map[string]any, t.NumField())
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+
+		properties[name] = g.typeSchema(field.Type, fieldPath)
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,73 @@
+// Package configkit provides what the services' config packages have in common, so each
+// service only declares its own fields, checks and allowed values.
+package configkit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrNegative is returned when a duration or count is negative.
+	ErrNegative = errors.New("must not be negative")
+	// ErrInvalidValue is returned when a field is not one of its allowed values.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// FieldError is a validation error of the configuration value at a YAML path.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+// Error returns the YAML path followed by the validation error.
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the validation error, so that errors.Is matches its sentinel.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validator collects every validation error instead of stopping at the first one.
+type Validator struct {
+	errs []error
+}
+
+// Add records err for the field at the YAML path.
+func (v *Validator) Add(path string, err error) {
+	v.errs = append(v.errs, &FieldError{Path: path, Err: err})
+}
+
+// Addf records err with a formatted detail for the field at the YAML path.
+func (v *Validator) Addf(path string, err error, format string, args ...any) {
+	v.Add(path, fmt.Errorf("%w: "+format, append([]any{err}, args...)...))
+}
+
+// AddError records an error that does not belong to a single field, e.g. a missing
+// environment variable.
+func (v *Validator) AddError(err error) {
+	v.errs = append(v.errs, err)
+}
+
+// NonNegative records ErrNegative if value is negative.
+func (v *Validator) NonNegative(path string, value int64) {
+	if value < 0 {
+		v.Add(path, ErrNegative)
+	}
+}
+
+// OneOf records ErrInvalidValue if value is not one of allowed.
+func (v *Validator) OneOf(path, value string, allowed ...string) {
+	if !slices.Contains(allowed, value) {
+		v.Addf(path, ErrInvalidValue, "%q (must be %s)", value, strings.Join(allowed, ", "))
+	}
+}
+
+// Err returns all recorded errors joined, or nil if there are none.
+func (v *Validator) Err() error {
+	return errors.Join(v.errs...)
+}
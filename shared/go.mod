@@ -0,0 +1,8 @@
+module phasor/shared
+
+go 1.25.5
+
+require (
+	github.com/monkescience/vital v0.0.0-20251223172315-8503480c42fe
+	gopkg.in/yaml.v3 v3.0.1
+)
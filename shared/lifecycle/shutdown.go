@@ -0,0 +1,104 @@
+// Package lifecycle runs the services' HTTP servers and shuts them down gracefully.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/monkescience/vital"
+)
+
+// ShutdownChecker reports the instance as not ready once a graceful shutdown has started,
+// so load balancers stop routing new requests to it while in-flight requests complete.
+type ShutdownChecker struct {
+	draining atomic.Bool
+}
+
+// NewShutdownChecker creates a new shutdown checker that reports ready until draining starts.
+func NewShutdownChecker() *ShutdownChecker {
+	return &ShutdownChecker{}
+}
+
+// Name returns the name of this health check.
+func (c *ShutdownChecker) Name() string {
+	return "shutdown"
+}
+
+// Check reports an error once draining has started.
+func (c *ShutdownChecker) Check(_ context.Context) (vital.Status, string) {
+	if c.draining.Load() {
+		return vital.StatusError, "shutting down"
+	}
+
+	return vital.StatusOK, ""
+}
+
+// StartDraining marks the instance as shutting down. It cannot be undone.
+func (c *ShutdownChecker) StartDraining() {
+	c.draining.Store(true)
+}
+
+// Run starts the server and blocks until SIGINT or SIGTERM is received, then shuts the
+// server down gracefully using Drain. Unlike vital.Server.Run it returns server errors
+// instead of exiting.
+func Run(
+	server *vital.Server,
+	shutdownChecker *ShutdownChecker,
+	drainPeriod time.Duration,
+	logger *slog.Logger,
+) error {
+	serverErrors := make(chan error, 1)
+
+	go func() {
+		err := server.Start()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-serverErrors:
+		return fmt.Errorf("server error: %w", err)
+	case sig := <-signals:
+		logger.Info("received shutdown signal", slog.String("signal", sig.String()))
+	}
+
+	return Drain(server, shutdownChecker, drainPeriod, logger)
+}
+
+// Drain flips readiness to failing and keeps serving for the drain period so that load
+// balancers can deregister the instance, then stops the server with vital.Server.Stop,
+// which waits at most the server's shutdown timeout for in-flight requests to complete.
+func Drain(
+	server *vital.Server,
+	shutdownChecker *ShutdownChecker,
+	drainPeriod time.Duration,
+	logger *slog.Logger,
+) error {
+	shutdownChecker.StartDraining()
+
+	logger.Info("draining connections", slog.String("drain_period", drainPeriod.String()))
+	time.Sleep(drainPeriod)
+
+	err := server.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+
+	logger.Info("server stopped gracefully")
+
+	return nil
+}
@@ -0,0 +1,18 @@
+// Package testutil provides test utilities shared by the service test servers.
+package testutil
+
+import (
+	"net/http/httptest"
+
+	"github.com/monkescience/vital"
+)
+
+// StartServer serves the vital server on a local test listener, so that the server can be
+// stopped with the same vital.Server.Stop the production binaries use.
+func StartServer(server *vital.Server) *httptest.Server {
+	testServer := httptest.NewUnstartedServer(server.Handler)
+	testServer.Config = server.Server
+	testServer.Start()
+
+	return testServer
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"phasor/splitter/proxy"
+	"syscall"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/monkescience/vital"
+)
+
+const (
+	defaultPort      = 8080
+	defaultAdminPort = 9090
+)
+
+func main() {
+	var (
+		upstreams []proxy.Upstream
+		matches   []proxy.HeaderMatch
+	)
+
+	port := flag.Int("port", defaultPort, "Port of the proxy")
+	adminPort := flag.Int("admin-port", defaultAdminPort, "Port of the admin API and health endpoints")
+	rawWeights := flag.String("weights", "", "Initial weights as name=weight[,name=weight...] (default: 1 each)")
+	affinityCookie := flag.String("affinity-cookie", "", "Cookie that pins a client to the upstream it was "+
+		"first routed to by weight (default: no affinity)")
+	logLevel := flag.String("log-level", "info", "Log level: debug, info, warn or error")
+	logFormat := flag.String("log-format", "json", "Log format: json or text")
+
+	flag.Func("upstream", "Upstream as name=url, repeatable", func(value string) error {
+		upstream, err := proxy.ParseUpstream(value)
+		upstreams = append(upstreams, upstream)
+
+		return err //nolint:wrapcheck // The error already names the flag value.
+	})
+	flag.Func("match-header", "Route requests with a header value to an upstream as Header:value=upstream, "+
+		"repeatable", func(value string) error {
+		match, err := proxy.ParseHeaderMatch(value)
+		matches = append(matches, match)
+
+		return err //nolint:wrapcheck // The error already names the flag value.
+	})
+
+	flag.Usage = usage
+
+	flag.Parse()
+
+	logger, err := setupLogger(*logLevel, *logFormat)
+	if err != nil {
+		log.Fatalf("failed to setup logger: %v", err)
+	}
+
+	splitter, err := newSplitter(upstreams, matches, *rawWeights, *affinityCookie, logger)
+	if err != nil {
+		log.Fatalf("failed to create splitter: %v", err)
+	}
+
+	proxyServer := vital.NewServer(proxyRouter(splitter, logger), vital.WithPort(*port), vital.WithLogger(logger))
+	adminServer := vital.NewServer(adminRouter(splitter, logger), vital.WithPort(*adminPort), vital.WithLogger(logger))
+
+	logger.Info("splitting traffic",
+		slog.Any("weights", splitter.Weights()),
+		slog.Int("header_matches", len(matches)),
+	)
+
+	err = run(logger, proxyServer, adminServer)
+	if err != nil {
+		log.Fatalf("failed to run splitter: %v", err)
+	}
+}
+
+func usage() {
+	output := flag.CommandLine.Output()
+
+	fmt.Fprintf(output, "Usage: %s -upstream name=url [-upstream name=url...] [flags]\n\n", os.Args[0])
+	fmt.Fprintln(output, "Splits traffic between upstreams by weight, e.g. a stable and a canary backend.")
+	fmt.Fprintln(output, "The admin API changes weights live: PUT /weights {\"stable\": 80, \"canary\": 20}")
+	fmt.Fprintln(output, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+// setupLogger creates a configured slog.Logger using vital's handler and sets it as default.
+func setupLogger(level, format string) (*slog.Logger, error) {
+	handler, err := vital.NewHandlerFromConfig(vital.LogConfig{Level: level, Format: format}, vital.WithBuiltinKeys())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger handler: %w", err)
+	}
+
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+
+	return logger, nil
+}
+
+// newSplitter creates the splitter with the initial weights from the -weights flag.
+func newSplitter(
+	upstreams []proxy.Upstream,
+	matches []proxy.HeaderMatch,
+	rawWeights string,
+	affinityCookie string,
+	logger *slog.Logger,
+) (*proxy.Splitter, error) {
+	weights, err := proxy.ParseWeights(rawWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -weights: %w", err)
+	}
+
+	splitter, err := proxy.NewSplitter(upstreams, matches, logger, proxy.WithAffinityCookie(affinityCookie))
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstreams or header matches: %w", err)
+	}
+
+	err = splitter.SetWeights(weights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -weights: %w", err)
+	}
+
+	return splitter, nil
+}
+
+// proxyRouter proxies every request through the splitter.
+func proxyRouter(splitter *proxy.Splitter, logger *slog.Logger) http.Handler {
+	router := chi.NewRouter()
+	router.Use(vital.Recovery(logger))
+	router.Use(vital.TraceContext())
+	router.Use(vital.RequestLogger(logger))
+	router.Handle("/*", splitter)
+
+	return router
+}
+
+// adminRouter serves the splitter's own health endpoints and the admin API.
+func adminRouter(splitter *proxy.Splitter, logger *slog.Logger) http.Handler {
+	router := chi.NewRouter()
+	router.Use(vital.Recovery(logger))
+	router.Mount("/health", vital.NewHealthHandler(vital.WithVersion(os.Getenv("VERSION"))))
+
+	router.Group(func(r chi.Router) {
+		r.Use(vital.TraceContext())
+		r.Use(vital.RequestLogger(logger))
+
+		adminHandler := proxy.NewAdminHandler(splitter)
+		r.Get("/weights", adminHandler.GetWeights)
+		r.Put("/weights", adminHandler.SetWeights)
+	})
+
+	return router
+}
+
+// run starts all servers and blocks until one fails or SIGINT or SIGTERM is received, then
+// stops all servers gracefully.
+func run(logger *slog.Logger, servers ...*vital.Server) error {
+	serverErrors := make(chan error, len(servers))
+
+	for _, server := range servers {
+		go func() {
+			err := server.Start()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErrors <- err
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	defer signal.Stop(signals)
+
+	var runErr error
+
+	select {
+	case err := <-serverErrors:
+		runErr = fmt.Errorf("server error: %w", err)
+	case sig := <-signals:
+		logger.Info("received shutdown signal", slog.String("signal", sig.String()))
+	}
+
+	for _, server := range servers {
+		err := server.Stop()
+		if err != nil {
+			runErr = errors.Join(runErr, fmt.Errorf("failed to stop server: %w", err))
+		}
+	}
+
+	return runErr
+}
@@ -0,0 +1,15 @@
+module phasor/splitter
+
+go 1.25.5
+
+require (
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/monkescience/testastic v0.0.0-20251216213937-22bb94593d66
+	github.com/monkescience/vital v0.0.0-20251223172315-8503480c42fe
+)
+
+require (
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/term v0.38.0 // indirect
+)
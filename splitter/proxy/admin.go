@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/monkescience/vital"
+)
+
+// WeightsResponse lists the current weight of every upstream.
+type WeightsResponse struct {
+	Weights map[string]int `json:"weights"`
+}
+
+// AdminHandler serves the admin API that reads and changes the weights of a splitter live.
+type AdminHandler struct {
+	splitter *Splitter
+}
+
+// NewAdminHandler creates a new admin handler for the given splitter.
+func NewAdminHandler(splitter *Splitter) *AdminHandler {
+	return &AdminHandler{
+		splitter: splitter,
+	}
+}
+
+// GetWeights returns the current weights.
+func (h *AdminHandler) GetWeights(writer http.ResponseWriter, _ *http.Request) {
+	respondJSON(writer, WeightsResponse{Weights: h.splitter.Weights()})
+}
+
+// SetWeights changes the weights of the upstreams named in the request body, e.g.
+// {"stable": 80, "canary": 20}, and returns the resulting weights.
+func (h *AdminHandler) SetWeights(writer http.ResponseWriter, req *http.Request) {
+	var weights map[string]int
+
+	err := json.NewDecoder(req.Body).Decode(&weights)
+	if err != nil {
+		vital.RespondProblem(writer, vital.BadRequest(fmt.Sprintf("invalid request body: %v", err)))
+
+		return
+	}
+
+	err = h.splitter.SetWeights(weights)
+	if err != nil {
+		vital.RespondProblem(writer, vital.BadRequest(err.Error()))
+
+		return
+	}
+
+	respondJSON(writer, WeightsResponse{Weights: h.splitter.Weights()})
+}
+
+// respondJSON writes the response as JSON with a 200 status code.
+func respondJSON(writer http.ResponseWriter, response any) {
+	writer.Header().Set("Content-Type", "application/json")
+
+	encodeErr := json.NewEncoder(writer).Encode(response)
+	if encodeErr != nil {
+		http.Error(writer, "failed to encode response", http.StatusInternalServerError)
+
+		return
+	}
+}
@@ -0,0 +1,63 @@
+package proxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"phasor/splitter/proxy"
+	"strings"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+func TestAdminHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("changes weights live", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an admin handler of a splitter with the default weights
+		splitter := newSplitter(t)
+		handler := proxy.NewAdminHandler(splitter)
+
+		// WHEN: moving a fifth of the traffic to the canary
+		recorder := httptest.NewRecorder()
+		handler.SetWeights(recorder, httptest.NewRequest(http.MethodPut, "/weights",
+			strings.NewReader(`{"stable": 80, "canary": 20}`)))
+
+		// THEN: the new weights are returned and applied
+		testastic.Equal(t, http.StatusOK, recorder.Code)
+		testastic.Equal(t, `{"weights":{"canary":20,"stable":80}}`+"\n", recorder.Body.String())
+		testastic.Equal(t, 20, splitter.Weights()["canary"])
+	})
+
+	t.Run("rejects unknown upstreams", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an admin handler
+		handler := proxy.NewAdminHandler(newSplitter(t))
+
+		// WHEN: setting the weight of an unknown upstream
+		recorder := httptest.NewRecorder()
+		handler.SetWeights(recorder, httptest.NewRequest(http.MethodPut, "/weights",
+			strings.NewReader(`{"preview": 100}`)))
+
+		// THEN: the request is rejected
+		testastic.Equal(t, http.StatusBadRequest, recorder.Code)
+		testastic.Contains(t, recorder.Body.String(), `unknown upstream \"preview\"`)
+	})
+
+	t.Run("returns the current weights", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an admin handler of a splitter with the default weights
+		handler := proxy.NewAdminHandler(newSplitter(t))
+
+		// WHEN: reading the weights
+		recorder := httptest.NewRecorder()
+		handler.GetWeights(recorder, httptest.NewRequest(http.MethodGet, "/weights", nil))
+
+		// THEN: every upstream has the weight 1
+		testastic.Equal(t, `{"weights":{"canary":1,"stable":1}}`+"\n", recorder.Body.String())
+	})
+}
@@ -0,0 +1,75 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidUpstream is returned for an upstream that is not name=http(s)://host[:port].
+	ErrInvalidUpstream = errors.New("upstream must be name=http(s)://host[:port]")
+	// ErrInvalidHeaderMatch is returned for a header match that is not Header:value=upstream.
+	ErrInvalidHeaderMatch = errors.New("header match must be Header:value=upstream")
+	// ErrInvalidWeights is returned for weights that are not name=weight[,name=weight...].
+	ErrInvalidWeights = errors.New("weights must be name=weight[,name=weight...]")
+)
+
+// ParseUpstream parses an upstream in the form name=url, e.g. stable=http://backend-stable:8080.
+func ParseUpstream(value string) (Upstream, error) {
+	name, rawURL, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return Upstream{}, fmt.Errorf("%w: %q", ErrInvalidUpstream, value)
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return Upstream{}, fmt.Errorf("%w: %q", ErrInvalidUpstream, value)
+	}
+
+	return Upstream{Name: name, URL: target}, nil
+}
+
+// ParseHeaderMatch parses a header match in the form Header:value=upstream, e.g.
+// X-Canary:always=canary. The value may contain '=' but not the header name.
+func ParseHeaderMatch(value string) (HeaderMatch, error) {
+	header, rest, ok := strings.Cut(value, ":")
+	if !ok || header == "" {
+		return HeaderMatch{}, fmt.Errorf("%w: %q", ErrInvalidHeaderMatch, value)
+	}
+
+	separator := strings.LastIndex(rest, "=")
+	if separator < 0 || separator == len(rest)-1 {
+		return HeaderMatch{}, fmt.Errorf("%w: %q", ErrInvalidHeaderMatch, value)
+	}
+
+	return HeaderMatch{
+		Header:   header,
+		Value:    rest[:separator],
+		Upstream: rest[separator+1:],
+	}, nil
+}
+
+// ParseWeights parses weights in the form name=weight[,name=weight...], e.g. stable=90,canary=10.
+// An empty value has no weights.
+func ParseWeights(value string) (map[string]int, error) {
+	weights := make(map[string]int)
+	if value == "" {
+		return weights, nil
+	}
+
+	for pair := range strings.SplitSeq(value, ",") {
+		name, rawWeight, ok := strings.Cut(pair, "=")
+
+		weight, err := strconv.Atoi(rawWeight)
+		if !ok || name == "" || err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWeights, value)
+		}
+
+		weights[name] = weight
+	}
+
+	return weights, nil
+}
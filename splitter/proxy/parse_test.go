@@ -0,0 +1,86 @@
+package proxy_test
+
+import (
+	"phasor/splitter/proxy"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("upstream", func(t *testing.T) {
+		t.Parallel()
+
+		// WHEN: parsing an upstream
+		upstream, err := proxy.ParseUpstream("canary=http://phasor-backend-canary:8080")
+
+		// THEN: the name and URL are split at the first '='
+		testastic.NoError(t, err)
+		testastic.Equal(t, "canary", upstream.Name)
+		testastic.Equal(t, "http://phasor-backend-canary:8080", upstream.URL.String())
+	})
+
+	t.Run("header match with '=' in the value", func(t *testing.T) {
+		t.Parallel()
+
+		// WHEN: parsing a header match whose value contains '='
+		match, err := proxy.ParseHeaderMatch("Cookie:canary=true=canary")
+
+		// THEN: the upstream follows the last '='
+		testastic.NoError(t, err)
+		testastic.Equal(t, proxy.HeaderMatch{Header: "Cookie", Value: "canary=true", Upstream: "canary"}, match)
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		t.Parallel()
+
+		// WHEN: parsing weights
+		weights, err := proxy.ParseWeights("stable=90,canary=10")
+
+		// THEN: every upstream has its weight
+		testastic.NoError(t, err)
+		testastic.Equal(t, 90, weights["stable"])
+		testastic.Equal(t, 10, weights["canary"])
+	})
+
+	invalid := []struct {
+		name    string
+		parse   func() error
+		wantErr error
+	}{
+		{
+			name:    "upstream without URL",
+			parse:   func() error { _, err := proxy.ParseUpstream("stable"); return err },
+			wantErr: proxy.ErrInvalidUpstream,
+		},
+		{
+			name:    "upstream without scheme",
+			parse:   func() error { _, err := proxy.ParseUpstream("stable=phasor-backend:8080"); return err },
+			wantErr: proxy.ErrInvalidUpstream,
+		},
+		{
+			name:    "header match without upstream",
+			parse:   func() error { _, err := proxy.ParseHeaderMatch("X-Canary:always"); return err },
+			wantErr: proxy.ErrInvalidHeaderMatch,
+		},
+		{
+			name:    "weights with a non-numeric weight",
+			parse:   func() error { _, err := proxy.ParseWeights("stable=most"); return err },
+			wantErr: proxy.ErrInvalidWeights,
+		},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name+" is rejected", func(t *testing.T) {
+			t.Parallel()
+
+			// WHEN: parsing an invalid value
+			err := tt.parse()
+
+			// THEN: the format is reported
+			testastic.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package proxy splits traffic between backend upstreams by weight and header matches, like
+// the traffic routing of a canary rollout.
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+
+	"github.com/monkescience/vital"
+)
+
+// UpstreamHeader is the response header naming the upstream that served the request.
+const UpstreamHeader = "X-Splitter-Upstream"
+
+var (
+	// ErrNoUpstreams is returned when a splitter is created without upstreams.
+	ErrNoUpstreams = errors.New("at least one upstream is required")
+	// ErrDuplicateUpstream is returned when two upstreams have the same name.
+	ErrDuplicateUpstream = errors.New("duplicate upstream")
+	// ErrUnknownUpstream is returned when a weight or header match names an unknown upstream.
+	ErrUnknownUpstream = errors.New("unknown upstream")
+	// ErrNegativeWeight is returned when a weight is below zero.
+	ErrNegativeWeight = errors.New("weight must not be negative")
+	// ErrEmptyHeaderMatch is returned when a header match has no header or value, which would
+	// match every request without the header.
+	ErrEmptyHeaderMatch = errors.New("header match needs a header and a value")
+)
+
+// Upstream is a named backend that receives a share of the traffic.
+type Upstream struct {
+	Name string
+	URL  *url.URL
+}
+
+// HeaderMatch sends every request whose Header has Value to the upstream named Upstream,
+// regardless of weights, like the header route of a canary.
+type HeaderMatch struct {
+	Header   string
+	Value    string
+	Upstream string
+}
+
+// Splitter is a reverse proxy that routes requests matching a header match to its upstream
+// and splits the remaining requests by weight using smooth weighted round robin, so that
+// weights 3 and 1 route four requests as A, A, B, A.
+type Splitter struct {
+	names   []string
+	proxies map[string]*httputil.ReverseProxy
+	matches []HeaderMatch
+	// affinityCookie pins clients to the upstream they were first routed to by weight, "" disables it
+	affinityCookie string
+
+	mu      sync.Mutex
+	weights map[string]int
+	current map[string]int
+}
+
+// Option configures a Splitter.
+type Option func(*Splitter)
+
+// WithAffinityCookie pins a client to the upstream it was first routed to by weight with the
+// cookie name, as long as that upstream has a weight. Header matches still take precedence,
+// and an empty name disables affinity.
+func WithAffinityCookie(name string) Option {
+	return func(s *Splitter) {
+		s.affinityCookie = name
+	}
+}
+
+// NewSplitter creates a splitter that initially gives every upstream the weight 1. Proxy
+// errors are logged with logger.
+func NewSplitter(
+	upstreams []Upstream,
+	matches []HeaderMatch,
+	logger *slog.Logger,
+	opts ...Option,
+) (*Splitter, error) {
+	if len(upstreams) == 0 {
+		return nil, ErrNoUpstreams
+	}
+
+	splitter := &Splitter{
+		names:   make([]string, 0, len(upstreams)),
+		proxies: make(map[string]*httputil.ReverseProxy, len(upstreams)),
+		matches: matches,
+		weights: make(map[string]int, len(upstreams)),
+		current: make(map[string]int, len(upstreams)),
+	}
+
+	for _, opt := range opts {
+		opt(splitter)
+	}
+
+	for _, upstream := range upstreams {
+		if _, ok := splitter.proxies[upstream.Name]; ok {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateUpstream, upstream.Name)
+		}
+
+		proxy := httputil.NewSingleHostReverseProxy(upstream.URL)
+		proxy.ErrorHandler = proxyErrorHandler(upstream.Name, logger)
+
+		splitter.names = append(splitter.names, upstream.Name)
+		splitter.proxies[upstream.Name] = proxy
+		splitter.weights[upstream.Name] = 1
+	}
+
+	for _, match := range matches {
+		if match.Header == "" || match.Value == "" {
+			return nil, fmt.Errorf("%w: %q", ErrEmptyHeaderMatch, match.Header+":"+match.Value)
+		}
+
+		if _, ok := splitter.proxies[match.Upstream]; !ok {
+			return nil, fmt.Errorf("header match %s: %w %q", match.Header, ErrUnknownUpstream, match.Upstream)
+		}
+	}
+
+	return splitter, nil
+}
+
+// Weights returns the current weight of every upstream.
+func (s *Splitter) Weights() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.weights)
+}
+
+// SetWeights changes the weights of the named upstreams, e.g. to step a canary from 10% to
+// 50%. Upstreams that are not named keep their weight. Either all weights are changed or,
+// if one is invalid, none.
+func (s *Splitter) SetWeights(weights map[string]int) error {
+	for name, weight := range weights {
+		if _, ok := s.proxies[name]; !ok {
+			return fmt.Errorf("%w %q", ErrUnknownUpstream, name)
+		}
+
+		if weight < 0 {
+			return fmt.Errorf("%w: %s=%d", ErrNegativeWeight, name, weight)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	maps.Copy(s.weights, weights)
+	clear(s.current)
+
+	return nil
+}
+
+// ServeHTTP proxies the request to the upstream of its header match or the next upstream by
+// weight, and names the upstream in the UpstreamHeader response header.
+func (s *Splitter) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	name, pinned := s.route(req)
+	if name == "" {
+		vital.RespondProblem(writer, vital.ServiceUnavailable("no upstream has a weight"))
+
+		return
+	}
+
+	if pinned && s.affinityCookie != "" {
+		http.SetCookie(writer, &http.Cookie{Name: s.affinityCookie, Value: name, Path: "/"})
+	}
+
+	writer.Header().Set(UpstreamHeader, name)
+	s.proxies[name].ServeHTTP(writer, req)
+}
+
+// route returns the upstream of req, and whether it was picked by weight so that the client
+// should be pinned to it. It returns "" if no header matches and no upstream has a weight.
+func (s *Splitter) route(req *http.Request) (string, bool) {
+	for _, match := range s.matches {
+		if req.Header.Get(match.Header) == match.Value {
+			return match.Upstream, false
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.affinityCookie != "" {
+		cookie, err := req.Cookie(s.affinityCookie)
+		if err == nil && s.weights[cookie.Value] > 0 {
+			return cookie.Value, false
+		}
+	}
+
+	var (
+		picked string
+		total  int
+	)
+
+	// Every upstream gains its weight, the one with the most is picked and loses the total.
+	for _, name := range s.names {
+		weight := s.weights[name]
+		if weight == 0 {
+			continue
+		}
+
+		total += weight
+		s.current[name] += weight
+
+		if picked == "" || s.current[name] > s.current[picked] {
+			picked = name
+		}
+	}
+
+	if picked != "" {
+		s.current[picked] -= total
+	}
+
+	return picked, picked != ""
+}
+
+// proxyErrorHandler logs a failed upstream request and responds with 502 Bad Gateway.
+func proxyErrorHandler(name string, logger *slog.Logger) func(http.ResponseWriter, *http.Request, error) {
+	return func(writer http.ResponseWriter, req *http.Request, err error) {
+		logger.ErrorContext(req.Context(), "upstream request failed",
+			slog.String("upstream", name),
+			slog.String("error", err.Error()),
+		)
+
+		vital.RespondProblem(writer, vital.NewProblemDetail(http.StatusBadGateway, "Bad Gateway").
+			WithDetail(fmt.Sprintf("upstream %s is unavailable", name)))
+	}
+}
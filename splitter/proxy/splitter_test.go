@@ -0,0 +1,199 @@
+package proxy_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"phasor/splitter/proxy"
+	"strings"
+	"testing"
+
+	"github.com/monkescience/testastic"
+)
+
+// newUpstream starts a server that answers every request with its name.
+func newUpstream(t *testing.T, name string) proxy.Upstream {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(writer, name)
+	}))
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	testastic.NoError(t, err)
+
+	return proxy.Upstream{Name: name, URL: target}
+}
+
+func newSplitter(t *testing.T, matches ...proxy.HeaderMatch) *proxy.Splitter {
+	t.Helper()
+
+	splitter, err := proxy.NewSplitter(
+		[]proxy.Upstream{newUpstream(t, "stable"), newUpstream(t, "canary")},
+		matches,
+		slog.New(slog.DiscardHandler),
+	)
+	testastic.NoError(t, err)
+
+	return splitter
+}
+
+// serve sends count requests with header through the splitter and returns the upstream
+// names that served them.
+func serve(splitter *proxy.Splitter, count int, header http.Header) []string {
+	served := make([]string, count)
+
+	for i := range served {
+		req := httptest.NewRequest(http.MethodGet, "/instance/info", nil)
+		req.Header = header.Clone()
+		recorder := httptest.NewRecorder()
+
+		splitter.ServeHTTP(recorder, req)
+		served[i] = recorder.Header().Get(proxy.UpstreamHeader) + ":" + recorder.Body.String()
+	}
+
+	return served
+}
+
+func TestSplitter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits requests smoothly by weight", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a splitter sending a quarter of the traffic to the canary
+		splitter := newSplitter(t)
+		testastic.NoError(t, splitter.SetWeights(map[string]int{"stable": 3, "canary": 1}))
+
+		// WHEN: sending eight requests
+		served := serve(splitter, 8, nil)
+
+		// THEN: the canary serves every fourth request
+		testastic.Equal(t,
+			"stable:stable stable:stable canary:canary stable:stable "+
+				"stable:stable stable:stable canary:canary stable:stable",
+			strings.Join(served, " "))
+	})
+
+	t.Run("header match routes to its upstream regardless of weights", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a splitter without canary weight and a header match for the canary
+		splitter := newSplitter(t, proxy.HeaderMatch{Header: "X-Canary", Value: "always", Upstream: "canary"})
+		testastic.NoError(t, splitter.SetWeights(map[string]int{"canary": 0}))
+
+		// WHEN: sending requests with and without the header
+		matched := serve(splitter, 2, http.Header{"X-Canary": {"always"}})
+		unmatched := serve(splitter, 2, http.Header{"X-Canary": {"never"}})
+
+		// THEN: only the matching requests are served by the canary
+		testastic.Equal(t, "canary:canary canary:canary", strings.Join(matched, " "))
+		testastic.Equal(t, "stable:stable stable:stable", strings.Join(unmatched, " "))
+	})
+
+	t.Run("affinity cookie pins a client to the upstream it was first routed to", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an evenly weighted splitter with cookie affinity
+		splitter, err := proxy.NewSplitter(
+			[]proxy.Upstream{newUpstream(t, "stable"), newUpstream(t, "canary")},
+			nil,
+			slog.New(slog.DiscardHandler),
+			proxy.WithAffinityCookie("phasor_upstream"),
+		)
+		testastic.NoError(t, err)
+
+		first := httptest.NewRecorder()
+		splitter.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/", nil))
+		testastic.Equal(t, "phasor_upstream=stable; Path=/", first.Header().Get("Set-Cookie"))
+
+		// WHEN: sending requests with the cookie of the first response
+		served := serve(splitter, 3, http.Header{"Cookie": {"phasor_upstream=stable"}})
+
+		// THEN: all requests are served by the upstream of the first one
+		testastic.Equal(t, "stable:stable stable:stable stable:stable", strings.Join(served, " "))
+	})
+
+	t.Run("affinity cookie of an upstream without weight is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a splitter with cookie affinity that shifted all weight to the canary
+		splitter, err := proxy.NewSplitter(
+			[]proxy.Upstream{newUpstream(t, "stable"), newUpstream(t, "canary")},
+			nil,
+			slog.New(slog.DiscardHandler),
+			proxy.WithAffinityCookie("phasor_upstream"),
+		)
+		testastic.NoError(t, err)
+		testastic.NoError(t, splitter.SetWeights(map[string]int{"stable": 0}))
+
+		// WHEN: sending a request pinned to the stable upstream
+		served := serve(splitter, 1, http.Header{"Cookie": {"phasor_upstream=stable"}})
+
+		// THEN: it is routed by weight instead
+		testastic.Equal(t, "canary:canary", served[0])
+	})
+
+	t.Run("without weights requests are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a splitter whose upstreams have no weight
+		splitter := newSplitter(t)
+		testastic.NoError(t, splitter.SetWeights(map[string]int{"stable": 0, "canary": 0}))
+
+		// WHEN: sending a request
+		recorder := httptest.NewRecorder()
+		splitter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// THEN: no upstream is available
+		testastic.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
+	t.Run("invalid weights change nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a splitter
+		splitter := newSplitter(t)
+
+		// WHEN: setting a valid and an unknown or negative weight
+		unknownErr := splitter.SetWeights(map[string]int{"stable": 5, "preview": 1})
+		negativeErr := splitter.SetWeights(map[string]int{"stable": 5, "canary": -1})
+
+		// THEN: both are rejected and the weights are unchanged
+		testastic.ErrorIs(t, unknownErr, proxy.ErrUnknownUpstream)
+		testastic.ErrorIs(t, negativeErr, proxy.ErrNegativeWeight)
+		testastic.Equal(t, 1, splitter.Weights()["stable"])
+		testastic.Equal(t, 1, splitter.Weights()["canary"])
+	})
+
+	t.Run("header match must name a known upstream", func(t *testing.T) {
+		t.Parallel()
+
+		// WHEN: creating a splitter with a header match for an unknown upstream
+		_, err := proxy.NewSplitter(
+			[]proxy.Upstream{newUpstream(t, "stable")},
+			[]proxy.HeaderMatch{{Header: "X-Canary", Value: "always", Upstream: "canary"}},
+			slog.New(slog.DiscardHandler),
+		)
+
+		// THEN: the header match is rejected
+		testastic.ErrorIs(t, err, proxy.ErrUnknownUpstream)
+	})
+
+	t.Run("header match must have a value", func(t *testing.T) {
+		t.Parallel()
+
+		// WHEN: creating a splitter with a header match for an empty value
+		_, err := proxy.NewSplitter(
+			[]proxy.Upstream{newUpstream(t, "stable"), newUpstream(t, "canary")},
+			[]proxy.HeaderMatch{{Header: "X-Canary", Value: "", Upstream: "canary"}},
+			slog.New(slog.DiscardHandler),
+		)
+
+		// THEN: the header match is rejected, as it would send every request without the header to the canary
+		testastic.ErrorIs(t, err, proxy.ErrEmptyHeaderMatch)
+	})
+}
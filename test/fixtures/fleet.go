@@ -0,0 +1,47 @@
+package fixtures
+
+import (
+	"fmt"
+	"testing"
+
+	backendserver "phasor/backend/testutil"
+)
+
+// fleetHostname is the hostname prefix of the backends of a fleet.
+const fleetHostname = "test-host"
+
+// Fleet is a set of backends behind an evenly weighted load balancer, like the pods of a
+// rollout behind their Service.
+type Fleet struct {
+	*LoadBalancer
+
+	Backends []*backendserver.Server
+}
+
+// NewFleet starts a backend per version behind a load balancer that spreads requests
+// round-robin over them. Every backend is configured with opts and reports its version and
+// the hostname "test-host-<n>", counting from 1, so that the backends can be told apart. The
+// fleet is closed when the test finishes.
+func NewFleet(t *testing.T, versions []string, opts ...backendserver.Option) *Fleet {
+	t.Helper()
+
+	backends := make([]*backendserver.Server, len(versions))
+	upstreams := make([]Upstream, len(versions))
+
+	for i, version := range versions {
+		hostname := fmt.Sprintf("%s-%d", fleetHostname, i+1)
+		backendOpts := append(opts[:len(opts):len(opts)],
+			backendserver.WithVersion(version),
+			backendserver.WithHostname(hostname),
+		)
+		backends[i] = backendserver.NewTestServer(backendOpts...)
+		t.Cleanup(backends[i].Close)
+
+		upstreams[i] = Upstream{Name: hostname, URL: backends[i].URL, Weight: 1}
+	}
+
+	return &Fleet{
+		LoadBalancer: NewLoadBalancer(t, LoadBalancerConfig{Upstreams: upstreams}),
+		Backends:     backends,
+	}
+}
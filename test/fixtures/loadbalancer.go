@@ -0,0 +1,118 @@
+package fixtures
+
+import (
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"phasor/splitter/proxy"
+	"sync"
+	"testing"
+)
+
+// Upstream is a named backend of a LoadBalancer with its initial weight, like a weighted
+// Service of an HTTPRoute or a Traefik weighted round robin.
+type Upstream struct {
+	Name   string
+	URL    string
+	Weight int
+}
+
+// LoadBalancerConfig configures how a LoadBalancer routes requests. Header matches are
+// checked in order before cookie affinity, and weights only apply to the remaining requests.
+type LoadBalancerConfig struct {
+	Upstreams     []Upstream
+	HeaderMatches []proxy.HeaderMatch
+	// AffinityCookie is the name of the cookie that pins a client to the upstream it was first
+	// routed to by weight, as long as that upstream has a weight. Empty disables affinity.
+	AffinityCookie string
+}
+
+// LoadBalancer runs the splitter of the local canary setup in process and counts the
+// requests every upstream served.
+type LoadBalancer struct {
+	*httptest.Server
+
+	t        *testing.T
+	splitter *proxy.Splitter
+
+	mu     sync.Mutex
+	served map[string]int
+}
+
+// NewLoadBalancer starts a load balancer for cfg, which is closed when the test finishes.
+// The test fails if an upstream URL is invalid, an upstream name is used twice, a weight is
+// negative or a header match names an unknown upstream.
+func NewLoadBalancer(t *testing.T, cfg LoadBalancerConfig) *LoadBalancer {
+	t.Helper()
+
+	upstreams := make([]proxy.Upstream, len(cfg.Upstreams))
+	weights := make(map[string]int, len(cfg.Upstreams))
+
+	for i, upstream := range cfg.Upstreams {
+		target, err := url.Parse(upstream.URL)
+		if err != nil {
+			t.Fatalf("invalid URL of upstream %q: %v", upstream.Name, err)
+		}
+
+		upstreams[i] = proxy.Upstream{Name: upstream.Name, URL: target}
+		weights[upstream.Name] = upstream.Weight
+	}
+
+	splitter, err := proxy.NewSplitter(
+		upstreams,
+		cfg.HeaderMatches,
+		slog.New(slog.DiscardHandler),
+		proxy.WithAffinityCookie(cfg.AffinityCookie),
+	)
+	if err != nil {
+		t.Fatalf("invalid load balancer: %v", err)
+	}
+
+	lb := &LoadBalancer{
+		t:        t,
+		splitter: splitter,
+		served:   make(map[string]int, len(cfg.Upstreams)),
+	}
+
+	lb.SetWeights(weights)
+
+	lb.Server = httptest.NewServer(http.HandlerFunc(lb.serveHTTP))
+	t.Cleanup(lb.Close)
+
+	return lb
+}
+
+// SetWeights changes the weights of the named upstreams like the splitter's admin API. The
+// test fails if a weight is invalid.
+func (lb *LoadBalancer) SetWeights(weights map[string]int) {
+	lb.t.Helper()
+
+	err := lb.splitter.SetWeights(weights)
+	if err != nil {
+		lb.t.Fatalf("invalid weights: %v", err)
+	}
+}
+
+// Served returns the number of requests every upstream has served.
+func (lb *LoadBalancer) Served() map[string]int {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	return maps.Clone(lb.served)
+}
+
+func (lb *LoadBalancer) serveHTTP(writer http.ResponseWriter, req *http.Request) {
+	lb.splitter.ServeHTTP(writer, req)
+
+	name := writer.Header().Get(proxy.UpstreamHeader)
+	if name == "" {
+		return
+	}
+
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	lb.served[name]++
+}
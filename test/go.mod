@@ -6,10 +6,16 @@ replace phasor/backend => ../backend
 
 replace phasor/frontend => ../frontend
 
+replace phasor/shared => ../shared
+
+replace phasor/splitter => ../splitter
+
 require (
 	github.com/monkescience/testastic v0.0.0-20251216213937-22bb94593d66
 	phasor/backend v0.0.0
 	phasor/frontend v0.0.0
+	phasor/shared v0.0.0
+	phasor/splitter v0.0.0
 )
 
 require (
@@ -21,13 +27,14 @@ require (
 	github.com/kr/text v0.2.0 // indirect
 	github.com/mailru/easyjson v0.9.1 // indirect
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
-	github.com/monkescience/vital v0.0.0-20251214151552-f348439ddad8 // indirect
+	github.com/monkescience/vital v0.0.0-20251223172315-8503480c42fe // indirect
 	github.com/oasdiff/yaml v0.0.0-20250309154309-f31be36b4037 // indirect
 	github.com/oasdiff/yaml3 v0.0.0-20250309153720-d2182401db90 // indirect
 	github.com/perimeterx/marshmallow v1.1.5 // indirect
 	github.com/stretchr/testify v1.11.1 // indirect
 	github.com/ugorji/go/codec v1.2.11 // indirect
 	github.com/woodsbury/decimal128 v1.4.0 // indirect
+	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/term v0.38.0 // indirect
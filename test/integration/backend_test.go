@@ -3,8 +3,11 @@ package integration_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	backendserver "phasor/backend/testutil"
+	sharedtestutil "phasor/shared/testutil"
+	"phasor/test/fixtures"
 
 	"github.com/monkescience/testastic"
 )
@@ -15,10 +18,17 @@ func TestBackendInstanceAPI(t *testing.T) {
 	t.Run("returns instance info with version", func(t *testing.T) {
 		t.Parallel()
 
-		// GIVEN: a backend server with version 1.2.3
-		server := backendserver.NewTestServer("1.2.3", backendserver.NewTestLogger(t))
+		// GIVEN: a backend server with version 1.2.3 that started 90 seconds ago
+		clock := sharedtestutil.NewClock(testStartTime)
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.2.3"),
+			backendserver.WithClock(clock.Now),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer server.Close()
 
+		clock.Advance(90 * time.Second)
+
 		// WHEN: requesting instance info
 		resp := httpGet(t, server.URL+"/instance/info")
 		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
@@ -32,8 +42,13 @@ func TestBackendInstanceAPI(t *testing.T) {
 	t.Run("returns consistent hostname across requests", func(t *testing.T) {
 		t.Parallel()
 
-		// GIVEN: a backend server
-		server := backendserver.NewTestServer("1.0.0", backendserver.NewTestLogger(t))
+		// GIVEN: a backend server with a stopped clock
+		clock := sharedtestutil.NewClock(testStartTime)
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithClock(clock.Now),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer server.Close()
 
 		// WHEN: requesting instance info twice
@@ -48,11 +63,39 @@ func TestBackendInstanceAPI(t *testing.T) {
 		testastic.AssertJSON(t, testdataPath("backend_consistent_hostname", "expected_response.json"), resp2.Body)
 	})
 
+	t.Run("echoes request with redacted sensitive headers", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server behind a proxy chain
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.2.3"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: requesting the echo endpoint with forwarded and sensitive headers
+		resp := httpGetWithHeaders(t, server.URL+"/instance/echo?debug=true&debug=false", http.Header{
+			"Authorization":     {"Bearer secret-token"},
+			"Cookie":            {"session=secret"},
+			"X-Canary":          {"always"},
+			"X-Forwarded-For":   {"203.0.113.7, 10.0.0.5"},
+			"X-Forwarded-Host":  {"phasor.example.com"},
+			"X-Forwarded-Proto": {"https"},
+		})
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response echoes the request without leaking secrets
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_instance_echo", "expected_response.json"), resp.Body)
+	})
+
 	t.Run("health live endpoint responds OK", func(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a backend server
-		server := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		server := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer server.Close()
 
 		// WHEN: requesting the live health endpoint
@@ -68,7 +111,9 @@ func TestBackendInstanceAPI(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a backend server
-		server := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		server := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer server.Close()
 
 		// WHEN: requesting the ready health endpoint
@@ -80,3 +125,357 @@ func TestBackendInstanceAPI(t *testing.T) {
 		testastic.AssertJSON(t, testdataPath("backend_health_ready", "expected_response.json"), resp.Body)
 	})
 }
+
+func TestBackendFleet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("spreads requests round-robin over backends with different versions", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a fleet of three backends with different versions
+		fleet := fixtures.NewFleet(
+			t,
+			[]string{"1.0.0", "1.1.0", "2.0.0"},
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+
+		// WHEN: requesting instance info once per backend
+		bodies := make([]string, len(fleet.Backends))
+		for i := range bodies {
+			resp := httpGet(t, fleet.URL+"/instance/info")
+			bodies[i] = readBody(t, resp)
+			resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+		}
+
+		// THEN: every backend answered once with its version and numbered hostname
+		testastic.Contains(t, bodies[0], `"hostname":"test-host-1"`)
+		testastic.Contains(t, bodies[0], `"version":"1.0.0"`)
+		testastic.Contains(t, bodies[1], `"hostname":"test-host-2"`)
+		testastic.Contains(t, bodies[1], `"version":"1.1.0"`)
+		testastic.Contains(t, bodies[2], `"hostname":"test-host-3"`)
+		testastic.Contains(t, bodies[2], `"version":"2.0.0"`)
+	})
+}
+
+func TestBackendStartup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("health ready endpoint fails while warming up", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with a warm-up period
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithStartup(time.Hour, 0),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: requesting the ready health endpoint
+		resp := httpGet(t, server.URL+"/health/ready")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response reports the remaining warm-up time
+		testastic.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_health_ready_warming_up", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("health ready endpoint reports self-check progress", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server requiring three self-checks
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithStartup(0, 3),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: requesting the ready health endpoint
+		resp := httpGet(t, server.URL+"/health/ready")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response reports the self-check progress
+		testastic.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_health_ready_self_checks", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("becomes ready after required self-checks pass", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server requiring three self-checks
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithStartup(0, 3),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: requesting the ready health endpoint three times
+		statuses := make([]int, 0, 3)
+
+		for range 3 {
+			resp := httpGet(t, server.URL+"/health/ready")
+			resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+			statuses = append(statuses, resp.StatusCode)
+		}
+
+		// THEN: only the third check reports ready
+		testastic.SliceEqual(t, []int{
+			http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable,
+			http.StatusOK,
+		}, statuses)
+	})
+}
+
+func TestBackendAdminAPI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejects requests without valid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with the admin API enabled
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithFaults("admin", "secret"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: posting a health fault with a wrong password
+		resp := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"readiness","duration":"1m"}`, "admin", "wrong")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: request is rejected and readiness is unaffected
+		testastic.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		ready := httpGet(t, server.URL+"/health/ready")
+		defer ready.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, ready.StatusCode)
+	})
+
+	t.Run("readiness fault fails health ready endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with the admin API enabled
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithFaults("admin", "secret"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: forcing readiness to fail
+		resp := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"readiness","duration":"1m"}`, "admin", "secret")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_admin_health_fault", "expected_response.json"), resp.Body)
+
+		// THEN: readiness reports the fault while liveness stays OK
+		ready := httpGet(t, server.URL+"/health/ready")
+		defer ready.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusServiceUnavailable, ready.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_health_ready_fault", "expected_response.json"), ready.Body)
+
+		live := httpGet(t, server.URL+"/health/live")
+		defer live.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, live.StatusCode)
+	})
+
+	t.Run("liveness fault fails health live endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with the admin API enabled
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithFaults("admin", "secret"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: forcing liveness to fail
+		resp := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"liveness","duration":"1m"}`, "admin", "secret")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// THEN: liveness reports an error status
+		live := httpGet(t, server.URL+"/health/live")
+		defer live.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusServiceUnavailable, live.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_health_live_fault", "expected_response.json"), live.Body)
+	})
+
+	t.Run("zero duration clears a fault", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with an active readiness fault
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithFaults("admin", "secret"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		set := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"readiness","duration":"1m"}`, "admin", "secret")
+		set.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// WHEN: clearing the fault
+		reset := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"readiness","duration":"0s"}`, "admin", "secret")
+		reset.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: readiness is OK again
+		ready := httpGet(t, server.URL+"/health/ready")
+		defer ready.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, ready.StatusCode)
+	})
+
+	t.Run("rejects unknown probe", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with the admin API enabled
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithFaults("admin", "secret"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: posting a fault for an unknown probe
+		resp := httpPostJSON(t, server.URL+"/admin/health", `{"probe":"startup","duration":"1m"}`, "admin", "secret")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: request is rejected as a bad request
+		testastic.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		testastic.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+	})
+}
+
+func TestBackendPressure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("starts CPU pressure", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with pressure simulation enabled
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithPressure(1, 4, time.Minute),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: starting CPU pressure on one core
+		resp := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/cpu", `{"cores":1,"duration":"200ms"}`)
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response reports the active CPU pressure
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_pressure_cpu", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("reports retained memory in instance info", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server retaining memory
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithPressure(1, 4, time.Minute),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		start := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/memory", `{"megabytes":2,"duration":"1m"}`)
+		start.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, start.StatusCode)
+
+		// WHEN: requesting instance info
+		resp := httpGet(t, server.URL+"/instance/info")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response reports the retained memory
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_instance_info_pressure", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("stops all pressure", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with active CPU and memory pressure
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithPressure(1, 4, time.Minute),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		cpu := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/cpu", `{"cores":1,"duration":"1m"}`)
+		cpu.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		memory := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/memory", `{"megabytes":1,"duration":"1m"}`)
+		memory.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// WHEN: stopping the pressure
+		resp := httpDoJSON(t, http.MethodDelete, server.URL+"/instance/pressure", "")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: no pressure is active anymore
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("backend_pressure_stopped", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("rejects requests exceeding the limits", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server limited to one core and 4 MB
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithPressure(1, 4, time.Minute),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: requesting more than the limits allow
+		cpu := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/cpu", `{"cores":2,"duration":"10s"}`)
+		defer cpu.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		memory := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/memory", `{"megabytes":8,"duration":"10s"}`)
+		defer memory.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		duration := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/cpu", `{"cores":1,"duration":"2m"}`)
+		defer duration.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: all requests are rejected as bad requests
+		testastic.Equal(t, http.StatusBadRequest, cpu.StatusCode)
+		testastic.Equal(t, http.StatusBadRequest, memory.StatusCode)
+		testastic.Equal(t, http.StatusBadRequest, duration.StatusCode)
+	})
+
+	t.Run("is disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server with default configuration
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		// WHEN: starting CPU pressure
+		resp := httpDoJSON(t, http.MethodPost, server.URL+"/instance/pressure/cpu", `{"cores":1,"duration":"1s"}`)
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: request is forbidden
+		testastic.Equal(t, http.StatusForbidden, resp.StatusCode)
+		testastic.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+	})
+}
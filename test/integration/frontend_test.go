@@ -3,11 +3,20 @@ package integration_test
 import (
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	backendserver "phasor/backend/testutil"
 	frontendserver "phasor/frontend/testutil"
+	sharedtestutil "phasor/shared/testutil"
+	"phasor/splitter/proxy"
+	"phasor/test/fixtures"
 
 	"github.com/monkescience/testastic"
 )
@@ -21,14 +30,17 @@ func TestFrontendHandler(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server connected to a backend
-		backend := backendserver.NewTestServer("1.0.0", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -43,18 +55,126 @@ func TestFrontendHandler(t *testing.T) {
 		testastic.AssertHTML(t, testdataPath("frontend_index", "expected_response.html"), resp.Body)
 	})
 
+	t.Run("index page is rendered from embedded templates", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server without a templates directory
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the index page
+		resp := httpGet(t, frontend.URL+"/")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the production page compiled into the binary is served completely
+		body := readBody(t, resp)
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Contains(t, body, "<title>Instance Dashboard</title>")
+		testastic.Contains(t, body, `id="tiles-container"`)
+		testastic.True(t, strings.HasSuffix(strings.TrimSpace(body), "</html>"))
+	})
+
+	t.Run("index page that fails to render responds with an error", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server whose index template links an unknown static asset
+		templatesDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(templatesDir, "index.gohtml"), []byte(
+			`<!DOCTYPE html><html><head><script src="{{static "missing.js"}}"></script></head></html>`,
+		), 0o600)
+		testastic.NoError(t, err)
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesDir),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the index page
+		resp := httpGet(t, frontend.URL+"/")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: an error is returned instead of a page cut off at the failure
+		body := readBody(t, resp)
+		testastic.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		testastic.Contains(t, body, "unknown static asset")
+		testastic.NotContains(t, body, "<html>")
+	})
+
+	t.Run("frontend info endpoint returns instance info", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server that started 5 minutes ago
+		clock := sharedtestutil.NewClock(testStartTime)
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithClock(clock.Now),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		clock.Advance(5 * time.Minute)
+
+		// WHEN: requesting the frontend info endpoint
+		resp := httpGet(t, frontend.URL+"/frontend/info")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: response mirrors the backend instance info with the frontend's version
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("frontend_info", "expected_response.json"), resp.Body)
+	})
+
+	t.Run("frontend info endpoint reports the injected hostname", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server running as a green pod
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithHostname("phasor-frontend-green-7d9f"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the frontend info endpoint
+		resp := httpGet(t, frontend.URL+"/frontend/info")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the pod's hostname is reported
+		testastic.Contains(t, readBody(t, resp), `"hostname":"phasor-frontend-green-7d9f"`)
+	})
+
 	t.Run("health live endpoint responds OK", func(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server
-		backend := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -73,19 +193,24 @@ func TestFrontendHandler(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server
-		backend := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
 		defer frontend.Close()
 
+		// The backends are probed in the background, readiness fails until the first probe.
+		waitForStatus(t, frontend.URL+"/health/ready", http.StatusOK)
+
 		// WHEN: requesting the ready health endpoint
 		resp := httpGet(t, frontend.URL+"/health/ready")
 		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
@@ -103,14 +228,17 @@ func TestFrontendTiles(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server with configured tile colors
-		backend := backendserver.NewTestServer("2.0.0", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			[]string{"#667eea", "#f093fb"},
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors([]string{"#667eea", "#f093fb"}...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -129,14 +257,17 @@ func TestFrontendTiles(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server
-		backend := backendserver.NewTestServer("1.0.0", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -155,14 +286,16 @@ func TestFrontendTiles(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server
-		backend := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -185,14 +318,16 @@ func TestFrontendTiles(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend server
-		backend := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -214,10 +349,10 @@ func TestFrontendTiles(t *testing.T) {
 
 		// GIVEN: a frontend server with unreachable backend
 		frontend, err := frontendserver.NewTestServer(
-			"http://localhost:59999/instance/info",
-			defaultTileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -233,6 +368,841 @@ func TestFrontendTiles(t *testing.T) {
 	})
 }
 
+func TestFrontendHeaderProfiles(t *testing.T) {
+	t.Parallel()
+
+	profiles := []frontendserver.HeaderProfile{
+		{Name: "stable"},
+		{Name: "canary", Headers: map[string]string{"X-Canary": "always"}},
+	}
+
+	t.Run("index page lists header profiles", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with header profiles
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithHeaderProfiles(profiles...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the index page
+		resp := httpGet(t, frontend.URL+"/")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: both profiles are selectable
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := readBody(t, resp)
+		testastic.Contains(t, body, `value="stable"`)
+		testastic.Contains(t, body, `value="canary"`)
+	})
+
+	t.Run("tiles are grouped per selected profile", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a header-based route to a stable and a canary backend
+		stable := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer stable.Close()
+
+		canary := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer canary.Close()
+
+		router := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stable.URL, Weight: 1},
+				{Name: "canary", URL: canary.URL, Weight: 0},
+			},
+			HeaderMatches: []proxy.HeaderMatch{
+				{Header: "X-Canary", Value: "always", Upstream: "canary"},
+			},
+		})
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(router.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithHeaderProfiles(profiles...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles for both profiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=2&profile=canary&profile=stable")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: each profile shows the version distribution of its route
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertHTML(t, testdataPath("frontend_tiles_header_profiles", "expected_response.html"), resp.Body)
+	})
+
+	t.Run("extra headers are attached to backend requests", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a header-based route to a stable and a canary backend
+		stable := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer stable.Close()
+
+		canary := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer canary.Close()
+
+		router := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stable.URL, Weight: 1},
+				{Name: "canary", URL: canary.URL, Weight: 0},
+			},
+			HeaderMatches: []proxy.HeaderMatch{
+				{Header: "X-Canary", Value: "always", Upstream: "canary"},
+			},
+		})
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(router.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles with a free-form canary header
+		resp := httpGet(t, frontend.URL+"/tiles?count=2&headers="+url.QueryEscape("x-canary: always"))
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: all tiles are served by the canary
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := readBody(t, resp)
+		testastic.Contains(t, body, "<code>X-Canary: always</code>")
+		testastic.Contains(t, body, "2.0.0: 2 (100%)")
+	})
+}
+
+func TestFrontendTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tiles are grouped per target", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server comparing a stable and a canary backend
+		stable := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer stable.Close()
+
+		canary := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer canary.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithTargets([]frontendserver.Target{
+				{Name: "stable", URL: stable.URL + "/instance/info"},
+				{Name: "canary", URL: canary.URL + "/instance/info"},
+			}...),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=1")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: each target shows its own version distribution in configuration order
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertHTML(t, testdataPath("frontend_tiles_targets", "expected_response.html"), resp.Body)
+	})
+
+	t.Run("readiness checks every target", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with a healthy target and a target exposed under a path prefix
+		stable := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer stable.Close()
+
+		prefixed := httptest.NewServer(http.StripPrefix("/api", stable.Config.Handler))
+		defer prefixed.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithTargets([]frontendserver.Target{
+				{Name: "stable", URL: stable.URL + "/instance/info"},
+				{Name: "gateway", URL: prefixed.URL + "/api/instance/info"},
+			}...),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// The backends are probed in the background, readiness fails until the first probe.
+		waitForStatus(t, frontend.URL+"/health/ready", http.StatusOK)
+
+		// WHEN: requesting the ready health endpoint
+		resp := httpGet(t, frontend.URL+"/health/ready")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: each target is checked at its own health URL
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertJSON(t, testdataPath("frontend_health_ready_targets", "expected_response.json"), resp.Body)
+	})
+}
+
+func TestFrontendPreview(t *testing.T) {
+	t.Parallel()
+
+	t.Run("preview page compares active and preview frontends", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an active and a preview frontend running different versions with the same config
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		active, err := frontendserver.NewTestServer(
+			frontendserver.WithVersion("1.0.0"),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer active.Close()
+
+		preview, err := frontendserver.NewTestServer(
+			frontendserver.WithVersion("2.0.0"),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer preview.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithPreview(active.URL, preview.URL),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the preview page
+		resp := httpGet(t, frontend.URL+"/preview")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: both versions, health and matching config checksums are shown side by side
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.AssertHTML(t, testdataPath("frontend_preview", "expected_response.html"), resp.Body)
+	})
+
+	t.Run("preview with the same version and a different config is ready to promote", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an active and a preview frontend running the same version with different tile colors
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		active, err := frontendserver.NewTestServer(
+			frontendserver.WithVersion("1.0.0"),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer active.Close()
+
+		preview, err := frontendserver.NewTestServer(
+			frontendserver.WithVersion("1.0.0"),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors("#000000"),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer preview.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithPreview(active.URL, preview.URL),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the preview page
+		resp := httpGet(t, frontend.URL+"/preview")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the config-only rollout can be promoted
+		body := readBody(t, resp)
+		testastic.Contains(t, body, "Ready to promote: true")
+		testastic.Contains(t, body, "Same version: true")
+		testastic.Contains(t, body, "Same config: false")
+	})
+
+	t.Run("preview page is not served without preview config", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server without preview URLs
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the preview page
+		resp := httpGet(t, frontend.URL+"/preview")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the page does not exist
+		testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestFrontendRolloutStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tiles show expected and observed canary traffic", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a stable and a canary pod behind a round-robin route and a rollout at 40% canary
+		stable := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithHostname("phasor-backend-aaaa-1"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer stable.Close()
+
+		canary := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithHostname("phasor-backend-bbbb-1"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer canary.Close()
+
+		router := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stable.URL, Weight: 1},
+				{Name: "canary", URL: canary.URL, Weight: 1},
+			},
+		})
+
+		statusFile := filepath.Join(t.TempDir(), "rollout.json")
+		err := os.WriteFile(statusFile, []byte(`{
+			"name": "phasor-backend",
+			"phase": "Paused",
+			"current_step": 1,
+			"total_steps": 4,
+			"canary_weight": 40,
+			"stable_hash": "aaaa",
+			"canary_hash": "bbbb"
+		}`), 0o600)
+		testastic.NoError(t, err)
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(router.URL+"/instance/info"),
+			frontendserver.WithRolloutStatusFile(statusFile),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the intended rollout state is shown next to the observed canary share
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := readBody(t, resp)
+		testastic.Contains(t, body, "Rollout phasor-backend: Paused, step 1/4")
+		testastic.Contains(t, body, "expected 40% canary / observed 50%")
+	})
+
+	t.Run("tiles are still rendered when the rollout status is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server whose rollout status file does not exist
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithRolloutStatusFile(filepath.Join(t.TempDir(), "missing.json")),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=1")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the error is reported and the tiles are shown without a comparison
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := readBody(t, resp)
+		testastic.Contains(t, body, `class="rollout-error"`)
+		testastic.NotContains(t, body, "expected")
+		testastic.Contains(t, body, "1.0.0: 1 (100%)")
+	})
+}
+
+func TestFrontendTileColors(t *testing.T) {
+	t.Parallel()
+
+	// 1.0.0 and 3.0.0 hash to the same color of a two-color palette.
+	twoColors := []string{"#111111", "#222222"}
+
+	t.Run("distinct mode gives colliding versions different colors", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend in distinct mode sampling two versions with colliding hashes
+		fleet := fixtures.NewFleet(
+			t,
+			[]string{"1.0.0", "3.0.0"},
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(fleet.URL+"/instance/info"),
+			frontendserver.WithColors("default", "distinct"),
+			frontendserver.WithTileColors(twoColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles twice
+		resp := httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		first := readBody(t, resp)
+
+		resp = httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		second := readBody(t, resp)
+
+		// THEN: both versions have their own color, which is kept across refreshes
+		versionColor := regexp.MustCompile(`color: (#[0-9]{6}); float: right;">3.0.0<`).FindStringSubmatch(first)
+		testastic.Equal(t, 2, len(versionColor))
+		testastic.Contains(t, first, `color: #111111; float: right;`)
+		testastic.Contains(t, first, `color: #222222; float: right;`)
+		testastic.Contains(t, second, versionColor[0])
+	})
+
+	t.Run("distinct mode keeps the color of a version when a new version appears", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend in distinct mode sampling only 3.0.0 while 1.0.0, which sorts first and
+		// hashes to the same color, has no weight yet
+		fleet := fixtures.NewFleet(
+			t,
+			[]string{"3.0.0", "1.0.0"},
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		fleet.SetWeights(map[string]int{"test-host-2": 0})
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(fleet.URL+"/instance/info"),
+			frontendserver.WithColors("default", "distinct"),
+			frontendserver.WithTileColors(twoColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		resp := httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Contains(t, readBody(t, resp), `color: #111111; float: right;">3.0.0<`)
+
+		// WHEN: 1.0.0 is rolled out and the tiles are refreshed
+		fleet.SetWeights(map[string]int{"test-host-2": 1})
+
+		resp = httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: 3.0.0 keeps its color and the new version gets the other one
+		body := readBody(t, resp)
+		testastic.Contains(t, body, `color: #111111; float: right;">3.0.0<`)
+		testastic.Contains(t, body, `color: #222222; float: right;">1.0.0<`)
+	})
+
+	t.Run("functional tile colors are rendered", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend whose only tile color is an rgb() color
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors("rgb(240, 147, 251)"),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=1")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the color is used as configured instead of being filtered out
+		body := readBody(t, resp)
+		testastic.Contains(t, body, `color: rgb(240, 147, 251); float: right;">1.0.0<`)
+		testastic.NotContains(t, body, "ZgotmplZ")
+	})
+
+	t.Run("hash mode colors colliding versions alike", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend in hash mode sampling two versions with colliding hashes
+		fleet := fixtures.NewFleet(
+			t,
+			[]string{"1.0.0", "3.0.0"},
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(fleet.URL+"/instance/info"),
+			frontendserver.WithColors("default", "hash"),
+			frontendserver.WithTileColors(twoColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: both versions share the color of their hash
+		body := readBody(t, resp)
+		testastic.Contains(t, body, `color: #111111; float: right;">1.0.0<`)
+		testastic.Contains(t, body, `color: #111111; float: right;">3.0.0<`)
+	})
+
+	t.Run("semver mode orders and colors versions by semantic version", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend in semver mode sampling 0.9.0 and 0.10.0, which sort the other way as strings,
+		// from test-host-2 and test-host-1, whose hostnames sort the other way as the versions
+		fleet := fixtures.NewFleet(
+			t,
+			[]string{"0.10.0", "0.9.0"},
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(fleet.URL+"/instance/info"),
+			frontendserver.WithColors("default", "semver"),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=2")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the tile of the newest version is listed first and the versions span the default gradient
+		body := readBody(t, resp)
+		newest := strings.Index(body, `color: #1dd1a1; float: right;">0.10.0<`)
+		oldest := strings.Index(body, `color: #ff6348; float: right;">0.9.0<`)
+		testastic.True(t, newest >= 0 && oldest > newest)
+	})
+
+	t.Run("dark theme uses the palette's dark variant", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend using the high-contrast palette
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithColors("high-contrast", "hash"),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles for the light and the dark theme
+		lightResp := httpGet(t, frontend.URL+"/tiles?count=1")
+		defer lightResp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		darkResp := httpGet(t, frontend.URL+"/tiles?count=1&theme=dark")
+		defer darkResp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the version has the same palette entry in its light and dark variant
+		testastic.Contains(t, readBody(t, lightResp), `color: #006688; float: right;">2.0.0<`)
+		testastic.Contains(t, readBody(t, darkResp), `color: #33ddee; float: right;">2.0.0<`)
+	})
+}
+
+func TestFrontendStaticAssets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("assets are served under content-hashed paths with long-cache headers", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with the embedded templates and assets
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		indexResp := httpGet(t, frontend.URL+"/")
+		defer indexResp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		stylesheet := linkedAsset(t, readBody(t, indexResp), `href="(/static/dashboard[.][0-9a-f]{12}[.]css)"`)
+
+		// WHEN: requesting the stylesheet linked from the index page
+		resp := httpGet(t, frontend.URL+stylesheet)
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: it is served from the binary and may be cached forever
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, "public, max-age=31536000, immutable", resp.Header.Get("Cache-Control"))
+		testastic.Contains(t, resp.Header.Get("Content-Type"), "text/css")
+	})
+
+	t.Run("vendored htmx is linked from the index page", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with the embedded templates and assets
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		indexResp := httpGet(t, frontend.URL+"/")
+		defer indexResp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Equal(t, http.StatusOK, indexResp.StatusCode)
+
+		script := linkedAsset(t, readBody(t, indexResp), `src="(/static/htmx[.]min[.][0-9a-f]{12}[.]js)"`)
+
+		// WHEN: requesting the htmx script linked from the index page
+		resp := httpGet(t, frontend.URL+script)
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the vendored htmx is served from the binary
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Contains(t, resp.Header.Get("Content-Type"), "javascript")
+		testastic.Contains(t, readBody(t, resp), "htmx")
+	})
+
+	t.Run("unhashed asset paths are not served", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting an asset by its plain name
+		resp := httpGet(t, frontend.URL+"/static/dashboard.css")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: it is not found, so stale copies can never be cached under a hashed name
+		testastic.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestFrontendSecurityHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("responses carry the default security headers", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with the default security headers
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the index page
+		resp := httpGet(t, frontend.URL+"/")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: all security headers are set and the CSP names no third-party origins
+		testastic.Equal(t, http.StatusOK, resp.StatusCode)
+		testastic.Equal(t, "max-age=31536000; includeSubDomains", resp.Header.Get("Strict-Transport-Security"))
+		testastic.Equal(t, "DENY", resp.Header.Get("X-Frame-Options"))
+		testastic.Equal(t, "strict-origin-when-cross-origin", resp.Header.Get("Referrer-Policy"))
+		testastic.Equal(t, "camera=(), geolocation=(), microphone=(), payment=(), usb=()",
+			resp.Header.Get("Permissions-Policy"))
+
+		csp := resp.Header.Get("Content-Security-Policy")
+		testastic.Contains(t, csp, "default-src 'self'")
+		testastic.NotContains(t, csp, "https:")
+		testastic.Contains(t, csp, "script-src 'self' 'nonce-")
+	})
+
+	t.Run("inline scripts are allowed by a per-request nonce", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server with the default security headers
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		nonces := make([]string, 0, 2)
+
+		for range 2 {
+			// WHEN: requesting the index page
+			resp := httpGet(t, frontend.URL+"/")
+			body := readBody(t, resp)
+			_ = resp.Body.Close()
+
+			// THEN: the script nonce in the page is the one allowed by the CSP
+			match := regexp.MustCompile(`script-src 'self' 'nonce-([^']+)'`).
+				FindStringSubmatch(resp.Header.Get("Content-Security-Policy"))
+			testastic.Equal(t, 2, len(match))
+			testastic.Contains(t, body, `<script nonce="`+match[1]+`">`)
+
+			nonces = append(nonces, match[1])
+		}
+
+		// THEN: every response gets a fresh nonce
+		testastic.NotEqual(t, nonces[0], nonces[1])
+	})
+
+	t.Run("headers can be overridden and disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server behind an ingress that already sets HSTS
+		headers := frontendserver.DefaultSecurityHeaders()
+		headers.StrictTransportSecurity = ""
+		headers.FrameOptions = "SAMEORIGIN"
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs("http://localhost:59999/instance/info"),
+			frontendserver.WithSecurityHeaders(headers),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting the liveness endpoint
+		resp := httpGet(t, frontend.URL+"/health/live")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the configured values are used on every route
+		testastic.Equal(t, "", resp.Header.Get("Strict-Transport-Security"))
+		testastic.Equal(t, "SAMEORIGIN", resp.Header.Get("X-Frame-Options"))
+	})
+}
+
 func readBody(t *testing.T, resp *http.Response) string {
 	t.Helper()
 
@@ -243,3 +1213,16 @@ func readBody(t *testing.T, resp *http.Response) string {
 
 	return buf.String()
 }
+
+// linkedAsset returns the asset path captured by pattern in the page, failing the test if the
+// page does not link the asset.
+func linkedAsset(t *testing.T, page, pattern string) string {
+	t.Helper()
+
+	match := regexp.MustCompile(pattern).FindStringSubmatch(page)
+	if len(match) != 2 {
+		t.Fatalf("page does not link an asset matching %s", pattern)
+	}
+
+	return match[1]
+}
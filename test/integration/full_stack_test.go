@@ -2,11 +2,16 @@ package integration_test
 
 import (
 	"net/http"
+	"net/http/cookiejar"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	backendserver "phasor/backend/testutil"
 	frontendserver "phasor/frontend/testutil"
+	"phasor/splitter/proxy"
+	"phasor/test/fixtures"
 
 	"github.com/monkescience/testastic"
 )
@@ -18,14 +23,17 @@ func TestFullStackFlow(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a full stack with frontend and backend servers
-		backend := backendserver.NewTestServer("2.0.0", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("2.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			[]string{"#667eea", "#f093fb", "#4facfe"},
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors([]string{"#667eea", "#f093fb", "#4facfe"}...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -47,16 +55,19 @@ func TestFullStackFlow(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend with multiple configured colors
-		backend := backendserver.NewTestServer("1.2.3", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.2.3"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		tileColors := []string{"#667eea", "#f093fb", "#4facfe", "#43e97b"}
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			tileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(tileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -92,16 +103,18 @@ func TestFullStackFlow(t *testing.T) {
 		t.Parallel()
 
 		// GIVEN: a frontend with custom color palette
-		backend := backendserver.NewTestServer("test-version", backendserver.NewTestLogger(t))
+		backend := backendserver.NewTestServer(
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
 		defer backend.Close()
 
 		tileColors := []string{"#ff0000", "#00ff00", "#0000ff", "#ffff00"}
 
 		frontend, err := frontendserver.NewTestServer(
-			backend.URL+"/instance/info",
-			tileColors,
-			templatesPath(),
-			frontendserver.NewTestLogger(t),
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(tileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
 		)
 		testastic.NoError(t, err)
 
@@ -128,3 +141,189 @@ func TestFullStackFlow(t *testing.T) {
 		testastic.True(t, hasConfiguredColor)
 	})
 }
+
+func TestFullStackTrafficSplitting(t *testing.T) {
+	t.Parallel()
+
+	t.Run("frontend observes the weighted canary share", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a rollout at 25% canary and a route sending every fourth request to the canary
+		stableURL, canaryURL := startStableAndCanary(t)
+		lb := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stableURL, Weight: 3},
+				{Name: "canary", URL: canaryURL, Weight: 1},
+			},
+		})
+
+		statusFile := filepath.Join(t.TempDir(), "rollout.json")
+		err := os.WriteFile(statusFile, []byte(`{
+			"name": "phasor-backend",
+			"phase": "Paused",
+			"current_step": 1,
+			"total_steps": 4,
+			"canary_weight": 25,
+			"stable_hash": "aaaa",
+			"canary_hash": "bbbb"
+		}`), 0o600)
+		testastic.NoError(t, err)
+
+		// The frontend probes the stable backend directly, so that only tiles go through the route.
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithTargets(frontendserver.Target{
+				Name:      "backend",
+				URL:       lb.URL + "/instance/info",
+				HealthURL: stableURL + "/health/ready",
+			}),
+			frontendserver.WithRolloutStatusFile(statusFile),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting eight tiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=8")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: exactly a quarter of the tiles were served by the canary
+		body := readBody(t, resp)
+		testastic.Contains(t, body, "expected 25% canary / observed 25%")
+		testastic.Contains(t, body, "1.0.0: 6 (75%)")
+		testastic.Contains(t, body, "2.0.0: 2 (25%)")
+		testastic.Equal(t, 6, lb.Served()["stable"])
+		testastic.Equal(t, 2, lb.Served()["canary"])
+	})
+
+	t.Run("header match routes a profile to the canary regardless of weights", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a route without canary weight that sends requests with X-Canary: always to the canary
+		stableURL, canaryURL := startStableAndCanary(t)
+		lb := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stableURL, Weight: 1},
+				{Name: "canary", URL: canaryURL, Weight: 0},
+			},
+			HeaderMatches: []proxy.HeaderMatch{
+				{Header: "X-Canary", Value: "always", Upstream: "canary"},
+			},
+		})
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(lb.URL+"/instance/info"),
+			frontendserver.WithHeaderProfiles(
+				frontendserver.HeaderProfile{Name: "stable"},
+				frontendserver.HeaderProfile{Name: "canary", Headers: map[string]string{"X-Canary": "always"}},
+			),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: requesting tiles with both profiles
+		resp := httpGet(t, frontend.URL+"/tiles?count=4&profile=stable&profile=canary")
+		defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: the canary profile only sees the canary, the stable profile only the stable version
+		body := readBody(t, resp)
+		testastic.Contains(t, body, "1.0.0: 4 (100%)")
+		testastic.Contains(t, body, "2.0.0: 4 (100%)")
+		testastic.Equal(t, 4, lb.Served()["canary"])
+	})
+
+	t.Run("changed weights promote the canary", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a route sending all traffic to the stable version
+		stableURL, canaryURL := startStableAndCanary(t)
+		lb := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stableURL, Weight: 1},
+				{Name: "canary", URL: canaryURL, Weight: 0},
+			},
+		})
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(lb.URL+"/instance/info"),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		before := httpGet(t, frontend.URL+"/tiles?count=4")
+		defer before.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		testastic.Contains(t, readBody(t, before), "1.0.0: 4 (100%)")
+
+		// WHEN: shifting all weight to the canary
+		lb.SetWeights(map[string]int{"stable": 0, "canary": 1})
+
+		after := httpGet(t, frontend.URL+"/tiles?count=4")
+		defer after.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+		// THEN: every tile is served by the canary
+		testastic.Contains(t, readBody(t, after), "2.0.0: 4 (100%)")
+	})
+
+	t.Run("affinity cookie pins a client to its first upstream", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: an evenly weighted route with cookie affinity and a client keeping cookies
+		stableURL, canaryURL := startStableAndCanary(t)
+		lb := fixtures.NewLoadBalancer(t, fixtures.LoadBalancerConfig{
+			Upstreams: []fixtures.Upstream{
+				{Name: "stable", URL: stableURL, Weight: 1},
+				{Name: "canary", URL: canaryURL, Weight: 1},
+			},
+			AffinityCookie: "phasor_upstream",
+		})
+
+		jar, err := cookiejar.New(nil)
+		testastic.NoError(t, err)
+
+		client := &http.Client{Jar: jar}
+
+		// WHEN: the client sends four requests
+		for range 4 {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, lb.URL+"/instance/info", nil)
+			testastic.NoError(t, err)
+
+			resp, err := client.Do(req)
+			testastic.NoError(t, err)
+			resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+		}
+
+		// THEN: all requests were served by the upstream of the first one
+		testastic.Equal(t, 4, lb.Served()["stable"])
+		testastic.Equal(t, 0, lb.Served()["canary"])
+	})
+}
+
+// startStableAndCanary starts a stable backend with version 1.0.0 and pod template hash aaaa
+// and a canary backend with version 2.0.0 and hash bbbb, and returns their URLs.
+func startStableAndCanary(t *testing.T) (string, string) {
+	t.Helper()
+
+	stable := backendserver.NewTestServer(
+		backendserver.WithVersion("1.0.0"),
+		backendserver.WithHostname("phasor-backend-aaaa-1"),
+		backendserver.WithLogger(backendserver.NewTestLogger(t)),
+	)
+	t.Cleanup(stable.Close)
+
+	canary := backendserver.NewTestServer(
+		backendserver.WithVersion("2.0.0"),
+		backendserver.WithHostname("phasor-backend-bbbb-1"),
+		backendserver.WithLogger(backendserver.NewTestLogger(t)),
+	)
+	t.Cleanup(canary.Close)
+
+	return stable.URL, canary.URL
+}
@@ -5,11 +5,16 @@ import (
 	"net/http"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/monkescience/testastic"
 )
 
+// testStartTime is the time test clocks start at, so goldens can contain exact timestamps.
+var testStartTime = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
 // templatesPath returns the path to test templates directory.
 func templatesPath() string {
 	//nolint:dogsled // runtime.Caller returns 4 values, we only need filename.
@@ -38,3 +43,53 @@ func httpGet(t *testing.T, url string) *http.Response {
 
 	return resp
 }
+
+// httpGetWithHeaders performs an HTTP GET request with the given headers.
+func httpGetWithHeaders(t *testing.T, url string, header http.Header) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	testastic.NoError(t, err)
+
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	testastic.NoError(t, err)
+
+	return resp
+}
+
+// httpPostJSON performs an HTTP POST request with a JSON body and basic auth credentials.
+func httpPostJSON(t *testing.T, url, body, username, password string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(body))
+	testastic.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(username, password)
+
+	resp, err := http.DefaultClient.Do(req)
+	testastic.NoError(t, err)
+
+	return resp
+}
+
+// httpDoJSON performs an HTTP request with the given method and JSON body.
+func httpDoJSON(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	testastic.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	testastic.NoError(t, err)
+
+	return resp
+}
@@ -0,0 +1,208 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	backendserver "phasor/backend/testutil"
+	frontendserver "phasor/frontend/testutil"
+
+	"github.com/monkescience/testastic"
+)
+
+const (
+	testDrainPeriod    = 500 * time.Millisecond
+	trafficWorkerCount = 4
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("backend serves in-flight requests while draining", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a backend server receiving continuous traffic
+		server := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer server.Close()
+
+		var served, failed atomic.Int64
+
+		stopTraffic := make(chan struct{})
+
+		var workers sync.WaitGroup
+
+		for range trafficWorkerCount {
+			workers.Go(func() {
+				for {
+					select {
+					case <-stopTraffic:
+						return
+					default:
+					}
+
+					if getStatus(server.URL+"/instance/info") == http.StatusOK {
+						served.Add(1)
+					} else {
+						failed.Add(1)
+					}
+				}
+			})
+		}
+
+		// WHEN: a graceful shutdown is started
+		shutdownErr := make(chan error, 1)
+
+		go func() {
+			shutdownErr <- server.Shutdown(testDrainPeriod)
+		}()
+
+		// THEN: readiness fails while instance info is still served
+		waitForStatus(t, server.URL+"/health/ready", http.StatusServiceUnavailable)
+
+		// The load balancer deregisters the instance and stops sending traffic.
+		close(stopTraffic)
+		workers.Wait()
+
+		testastic.NoError(t, <-shutdownErr)
+		testastic.Equal(t, int64(0), failed.Load())
+		testastic.Greater(t, served.Load(), int64(0))
+	})
+
+	t.Run("frontend readiness fails while draining", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend server connected to a backend
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(backend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithTemplates(templatesPath()),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		// WHEN: a graceful shutdown is started
+		shutdownErr := make(chan error, 1)
+
+		go func() {
+			shutdownErr <- frontend.Shutdown(testDrainPeriod)
+		}()
+
+		// THEN: readiness fails while pages are still served
+		waitForStatus(t, frontend.URL+"/health/ready", http.StatusServiceUnavailable)
+		testastic.Equal(t, http.StatusOK, getStatus(frontend.URL+"/"))
+		testastic.NoError(t, <-shutdownErr)
+	})
+
+	t.Run("frontend completes a request in flight when the server stops", func(t *testing.T) {
+		t.Parallel()
+
+		// GIVEN: a frontend whose backend holds instance info requests until released
+		backend := backendserver.NewTestServer(
+			backendserver.WithVersion("1.0.0"),
+			backendserver.WithLogger(backendserver.NewTestLogger(t)),
+		)
+		defer backend.Close()
+
+		entered := make(chan struct{}, 1)
+		release := make(chan struct{})
+		slowBackend := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/instance/info" {
+				entered <- struct{}{}
+
+				<-release
+			}
+
+			backend.Config.Handler.ServeHTTP(writer, req)
+		}))
+		defer slowBackend.Close()
+
+		frontend, err := frontendserver.NewTestServer(
+			frontendserver.WithBackendURLs(slowBackend.URL+"/instance/info"),
+			frontendserver.WithTileColors(defaultTileColors...),
+			frontendserver.WithLogger(frontendserver.NewTestLogger(t)),
+		)
+		testastic.NoError(t, err)
+
+		defer frontend.Close()
+
+		status := make(chan int, 1)
+
+		go func() {
+			status <- getStatus(frontend.URL + "/tiles?count=1")
+		}()
+
+		<-entered
+
+		// WHEN: the server is shut down while the request is in flight
+		shutdownErr := make(chan error, 1)
+
+		go func() {
+			shutdownErr <- frontend.Shutdown(testDrainPeriod)
+		}()
+
+		// The server stops accepting connections once the drain period is over.
+		waitForStatus(t, frontend.URL+"/health/live", 0)
+		close(release)
+
+		// THEN: the in-flight request completes before the server stops
+		testastic.Equal(t, http.StatusOK, <-status)
+		testastic.NoError(t, <-shutdownErr)
+	})
+}
+
+// drainClient does not reuse connections, so no idle pre-dialed connection delays shutdown.
+var drainClient = &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+// getStatus performs an HTTP GET request and returns the status code, or 0 if the request failed.
+func getStatus(url string) int {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return 0
+	}
+
+	resp, err := drainClient.Do(req)
+	if err != nil {
+		return 0
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // Ignoring close error in test cleanup.
+
+	return resp.StatusCode
+}
+
+// waitForStatus polls the URL until it responds with the expected status code.
+func waitForStatus(t *testing.T, url string, expected int) {
+	t.Helper()
+
+	const (
+		pollInterval = 10 * time.Millisecond
+		pollTimeout  = 5 * time.Second
+	)
+
+	deadline := time.Now().Add(pollTimeout)
+	for time.Now().Before(deadline) {
+		if getStatus(url) == expected {
+			return
+		}
+
+		time.Sleep(pollInterval)
+	}
+
+	t.Fatalf("timed out waiting for %s to respond with status %d", url, expected)
+}